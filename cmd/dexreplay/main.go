@@ -0,0 +1,244 @@
+// dexreplay は取引所の生のレスポンスを記録し、オフラインで再生してパース結果をゴールデンファイルと比較する。
+// 同じ比較は go test ./internal/infrastructure/dex/ でも実行される。
+// 取引所の JSON の変更によるパースの退行を本番より前に検出するためのもの
+//
+//	go run ./cmd/dexreplay                 # 記録を再生してゴールデンファイルと比較（不一致なら終了コード 1）
+//	go run ./cmd/dexreplay -update         # パーサーの意図した変更後にゴールデンファイルを書き直す
+//	go run ./cmd/dexreplay -record         # 本番 API に接続して記録とゴールデンファイルを取り直す
+//	go run ./cmd/dexreplay -record -missing # ゴールデンファイルのないケースだけを記録する（既存の記録は書き換えない）
+//	go run ./cmd/dexreplay -record -base-url hyperliquid=http://localhost:9090/hyperliquid
+//	go run ./cmd/dexreplay -record -exchange vertex -base-url vertex=http://localhost:9090/vertex -data-url vertex=http://localhost:9090/vertex/archive
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"btc-dex-dashboard/internal/infrastructure/dex"
+)
+
+// baseURLs は -base-url / -data-url exchange=url の指定
+type baseURLs map[string]string
+
+func (b baseURLs) String() string {
+	return fmt.Sprint(map[string]string(b))
+}
+
+func (b baseURLs) Set(v string) error {
+	exchange, url, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("expected exchange=url, got %q", v)
+	}
+	b[exchange] = url
+	return nil
+}
+
+func main() {
+	testdata := flag.String("testdata", "internal/infrastructure/dex/testdata", "directory with cases.json, recordings/ and golden/")
+	record := flag.Bool("record", false, "call the live APIs and overwrite recordings and golden files")
+	update := flag.Bool("update", false, "overwrite golden files with the replayed results")
+	exchanges := flag.String("exchange", "", "comma-separated exchanges to run (all if empty)")
+	missing := flag.Bool("missing", false, "only run cases without a golden file; -record keeps existing recordings")
+	urls := baseURLs{}
+	flag.Var(urls, "base-url", "exchange=url to record from (repeatable)")
+	dataURLs := baseURLs{}
+	flag.Var(dataURLs, "data-url", "exchange=url of the secondary REST API to record from (repeatable)")
+	flag.Parse()
+
+	cases, err := dex.LoadReplayCases(filepath.Join(*testdata, "cases.json"))
+	if err != nil {
+		log.Fatal("failed to load cases:", err)
+	}
+	if *exchanges != "" {
+		cases = filterCases(cases, strings.Split(*exchanges, ","))
+	}
+
+	recordings := filepath.Join(*testdata, "recordings")
+	goldenDir := filepath.Join(*testdata, "golden")
+	failed := 0
+
+	// 追加したケースのために既存のゴールデンファイルを取り直さない
+	if *missing {
+		cases = filterMissing(cases, goldenDir)
+		if len(cases) == 0 {
+			fmt.Println("no cases without a golden file")
+			return
+		}
+	}
+
+	// 同じリクエストの記録は1つにまとまるため、記録を終えてから再生した結果をゴールデンファイルにする
+	if *record {
+		recordDir := recordings
+		if *missing {
+			// 既存のケースと共有するリクエストの記録を上書きしないよう、一時ディレクトリに記録してから新しいものだけを移す
+			tmp, err := os.MkdirTemp("", "dexreplay")
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer os.RemoveAll(tmp)
+			recordDir = tmp
+		}
+		clients := newClients(func(exchange string) []dex.Option {
+			return []dex.Option{dex.WithRecording(recordDir), dex.WithBaseURL(urls[exchange]), dex.WithDataURL(dataURLs[exchange])}
+		})
+		for _, tc := range cases {
+			if _, err := run(clients.get(tc.Exchange), tc); err != nil {
+				fmt.Printf("FAIL %s: %v\n", tc.Name(), err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			fmt.Printf("%d of %d cases failed to record\n", failed, len(cases))
+			os.Exit(1)
+		}
+		if recordDir != recordings {
+			if err := copyNewRecordings(recordDir, recordings); err != nil {
+				log.Fatal("failed to copy recordings:", err)
+			}
+		}
+		*update = true
+	}
+
+	clients := newClients(func(string) []dex.Option {
+		return []dex.Option{dex.WithReplay(recordings)}
+	})
+	skipped := 0
+	for _, tc := range cases {
+		goldenPath := filepath.Join(goldenDir, tc.Name()+".json")
+		// 本番 API からまだ記録していないケース（-record -missing で記録する）
+		if _, err := os.Stat(goldenPath); !*record && errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("skip %s: not recorded yet\n", tc.Name())
+			skipped++
+			continue
+		}
+
+		got, err := run(clients.get(tc.Exchange), tc)
+		if err != nil {
+			fmt.Printf("FAIL %s: %v\n", tc.Name(), err)
+			failed++
+			continue
+		}
+
+		if *update {
+			if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+				log.Fatal(err)
+			}
+			if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("wrote %s\n", goldenPath)
+			continue
+		}
+
+		want, err := os.ReadFile(goldenPath)
+		if err != nil {
+			fmt.Printf("FAIL %s: %v\n", tc.Name(), err)
+			failed++
+			continue
+		}
+		if !bytes.Equal(want, got) {
+			fmt.Printf("FAIL %s: result differs from %s\n--- want\n%s--- got\n%s", tc.Name(), goldenPath, want, got)
+			failed++
+			continue
+		}
+		fmt.Printf("ok   %s\n", tc.Name())
+	}
+
+	if skipped > 0 {
+		fmt.Printf("%d of %d cases are not recorded yet (go run ./cmd/dexreplay -record -missing)\n", skipped, len(cases))
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d cases failed\n", failed, len(cases))
+		os.Exit(1)
+	}
+}
+
+// clientSet は種類ごとにクライアントを1つずつ生成して使い回す
+type clientSet struct {
+	opts    func(exchange string) []dex.Option
+	clients map[string]dex.DexClient
+}
+
+func newClients(opts func(exchange string) []dex.Option) *clientSet {
+	return &clientSet{opts: opts, clients: make(map[string]dex.DexClient)}
+}
+
+func (s *clientSet) get(exchange string) dex.DexClient {
+	if c, ok := s.clients[exchange]; ok {
+		return c
+	}
+	// 記録・再生とも1回の通信で結果を決める（再試行・ブレーカーなし）
+	opts := append([]dex.Option{dex.WithTransportConfig(dex.TransportConfig{Timeout: 10 * time.Second})}, s.opts(exchange)...)
+	c, err := dex.NewClient(exchange, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.clients[exchange] = c
+	return c
+}
+
+func filterCases(cases []dex.ReplayCase, exchanges []string) []dex.ReplayCase {
+	var result []dex.ReplayCase
+	for _, tc := range cases {
+		for _, e := range exchanges {
+			if tc.Exchange == strings.TrimSpace(e) {
+				result = append(result, tc)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// filterMissing はゴールデンファイルのないケースを返す
+func filterMissing(cases []dex.ReplayCase, goldenDir string) []dex.ReplayCase {
+	var result []dex.ReplayCase
+	for _, tc := range cases {
+		if _, err := os.Stat(filepath.Join(goldenDir, tc.Name()+".json")); errors.Is(err, os.ErrNotExist) {
+			result = append(result, tc)
+		}
+	}
+	return result
+}
+
+// copyNewRecordings は src/<取引所>/ の記録のうち dst にまだないものをコピーする
+func copyNewRecordings(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if _, err := os.Stat(target); err == nil {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		fmt.Printf("recorded %s\n", target)
+		return os.WriteFile(target, data, 0o644)
+	})
+}
+
+// run は1ケースを実行してパース結果を返す
+func run(client dex.DexClient, tc dex.ReplayCase) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return dex.RunReplayCase(ctx, client, tc)
+}
@@ -0,0 +1,34 @@
+// mockdex は Hyperliquid / Lighter / Aster / Binance（REST・WebSocket）と dYdX / Paradex / Vertex / Drift / Bybit / OKX（REST）の API を模倣するローカルのモック取引所。
+// 台本（シナリオ）に沿って価格をランダムウォークさせ、スプレッドの拡大・停止・壊れた JSON・429 を再現する。
+//
+//	go run ./cmd/mockdex -scenario cmd/mockdex/scenario.yaml
+//	CONFIG_FILE=config.mock.yaml go run ./cmd/server
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	scenarioPath := flag.String("scenario", "", "scenario YAML (random walk only if empty)")
+	flag.Parse()
+
+	scenario, err := loadScenario(*scenarioPath)
+	if err != nil {
+		log.Fatal("failed to load scenario:", err)
+	}
+
+	m := newMarket(scenario)
+	go m.run(context.Background())
+
+	s := newServer(m)
+	log.Printf("[mockdex] listening on %s (%d assets, %d events)", *addr, len(scenario.Assets), len(scenario.Events))
+	log.Printf("[mockdex] base URLs: http://localhost%s/{hyperliquid,lighter,aster,dydx,paradex,vertex,drift,binance,bybit,okx}", *addr)
+	if err := http.ListenAndServe(*addr, s.routes()); err != nil {
+		log.Fatal(err)
+	}
+}
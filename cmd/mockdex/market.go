@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const (
+	// bookLevelStepBps は板の1段ごとの価格差
+	bookLevelStepBps = 0.5
+	// bookMaxLevels は板の最大段数
+	bookMaxLevels = 1000
+)
+
+type level struct {
+	Price float64
+	Size  float64
+}
+
+// quote は取引所の気配値
+type quote struct {
+	Bid float64
+	Ask float64
+	Ts  time.Time
+}
+
+// market は台本に沿って全取引所で共通の仲値を動かし、取引所ごとの気配値と障害を返す
+type market struct {
+	scenario Scenario
+	start    time.Time
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	mids   map[string]float64 // 銘柄 → 共通の仲値
+	active map[int]bool       // 有効なイベント（ログ用）
+}
+
+func newMarket(scenario Scenario) *market {
+	mids := make(map[string]float64, len(scenario.Assets))
+	for _, a := range scenario.Assets {
+		mids[a.Name] = a.Start
+	}
+	return &market{
+		scenario: scenario,
+		start:    time.Now(),
+		rng:      rand.New(rand.NewPCG(scenario.Seed, scenario.Seed)),
+		mids:     mids,
+		active:   make(map[int]bool),
+	}
+}
+
+func (m *market) tick() time.Duration {
+	return time.Duration(m.scenario.TickMs) * time.Millisecond
+}
+
+// run は ctx が終了するまでティックごとに仲値を動かす
+func (m *market) run(ctx context.Context) {
+	ticker := time.NewTicker(m.tick())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.step()
+		}
+	}
+}
+
+func (m *market) step() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range m.scenario.Assets {
+		m.mids[a.Name] *= math.Exp(a.VolatilityBps / 1e4 * m.rng.NormFloat64())
+	}
+
+	elapsed := time.Since(m.start)
+	for i, e := range m.scenario.Events {
+		active := e.active(elapsed)
+		if active != m.active[i] {
+			if active {
+				log.Printf("[mockdex] event started: %s", e)
+			} else {
+				log.Printf("[mockdex] event ended: %s", e)
+			}
+			m.active[i] = active
+		}
+	}
+}
+
+func (m *market) asset(name string) (AssetScenario, bool) {
+	for _, a := range m.scenario.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return AssetScenario{}, false
+}
+
+// quote は取引所の最良気配を返す。扱っていない銘柄は ok = false
+func (m *market) quote(venue, asset string) (quote, bool) {
+	a, ok := m.asset(asset)
+	if !ok {
+		return quote{}, false
+	}
+	v := m.scenario.Venues[venue]
+
+	offsetBps := v.OffsetBps
+	elapsed := time.Since(m.start)
+	for _, e := range m.scenario.Events {
+		if e.Kind == eventSpread && e.matches(venue, asset) && e.active(elapsed) {
+			offsetBps = e.OffsetBps
+		}
+	}
+
+	m.mu.Lock()
+	mid := m.mids[asset] * (1 + (offsetBps+v.NoiseBps*m.rng.NormFloat64())/1e4)
+	m.mu.Unlock()
+
+	half := mid * a.HalfSpreadBps / 1e4
+	return quote{Bid: mid - half, Ask: mid + half, Ts: time.Now()}, true
+}
+
+// book は最良気配から bookLevelStepBps 刻みで depth 段の板を返す
+func (m *market) book(venue, asset string, depth int) (bids, asks []level, q quote, ok bool) {
+	q, ok = m.quote(venue, asset)
+	if !ok {
+		return nil, nil, quote{}, false
+	}
+	depth = min(max(depth, 1), bookMaxLevels)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	step := (q.Bid + q.Ask) / 2 * bookLevelStepBps / 1e4
+	for i := 0; i < depth; i++ {
+		bids = append(bids, level{Price: q.Bid - float64(i)*step, Size: 0.1 + m.rng.Float64()*2})
+		asks = append(asks, level{Price: q.Ask + float64(i)*step, Size: 0.1 + m.rng.Float64()*2})
+	}
+	return bids, asks, q, true
+}
+
+// fundingRate は取引所の1時間あたりの Funding Rate を返す
+func (m *market) fundingRate(venue string) float64 {
+	return m.scenario.Venues[venue].FundingRate
+}
+
+// fault は venue へのリクエストに適用する障害を返す。なければ nil
+func (m *market) fault(venue string) *Event {
+	elapsed := time.Since(m.start)
+	for i, e := range m.scenario.Events {
+		if e.Kind == eventSpread || !e.matches(venue, "") || !e.active(elapsed) {
+			continue
+		}
+		if e.Probability > 0 {
+			m.mu.Lock()
+			hit := m.rng.Float64() < e.Probability
+			m.mu.Unlock()
+			if !hit {
+				continue
+			}
+		}
+		return &m.scenario.Events[i]
+	}
+	return nil
+}
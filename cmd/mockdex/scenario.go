@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// イベントの種類
+const (
+	eventSpread    = "spread"     // 取引所の仲値からの乖離を offset_bps に上書きする
+	eventOutage    = "outage"     // 503 を返し、WebSocket を切断する
+	eventMalformed = "malformed"  // 壊れた JSON を返す
+	eventRateLimit = "rate_limit" // 429 と Retry-After を返す
+	eventLatency   = "latency"    // delay_ms だけ応答を遅らせる
+)
+
+// Scenario は価格の動きと障害の台本
+type Scenario struct {
+	Seed   uint64                   `mapstructure:"seed"`
+	TickMs int                      `mapstructure:"tick_ms"` // 価格の更新間隔（WebSocket の配信間隔）
+	Assets []AssetScenario          `mapstructure:"assets"`
+	Venues map[string]VenueScenario `mapstructure:"venues"` // hyperliquid / lighter / aster / dydx / paradex / vertex / drift / binance / bybit / okx
+	Events []Event                  `mapstructure:"events"`
+}
+
+// AssetScenario は銘柄ごとのランダムウォーク。全取引所で共通の仲値を動かす
+type AssetScenario struct {
+	Name          string  `mapstructure:"name"`
+	Start         float64 `mapstructure:"start"`
+	VolatilityBps float64 `mapstructure:"volatility_bps"` // 1ティックあたりの変動の標準偏差
+	HalfSpreadBps float64 `mapstructure:"half_spread_bps"`
+}
+
+// VenueScenario は取引所ごとの価格の癖
+type VenueScenario struct {
+	OffsetBps   float64 `mapstructure:"offset_bps"`   // 共通の仲値からの乖離
+	NoiseBps    float64 `mapstructure:"noise_bps"`    // リクエストごとの揺らぎの標準偏差
+	FundingRate float64 `mapstructure:"funding_rate"` // 1時間あたり
+}
+
+// Event は起動から at 経過後に duration の間（every があれば周期的に）有効になる
+type Event struct {
+	Kind        string        `mapstructure:"kind"`
+	Venue       string        `mapstructure:"venue"` // 空なら全取引所
+	Asset       string        `mapstructure:"asset"` // spread のみ。空なら全銘柄
+	At          time.Duration `mapstructure:"at"`
+	Duration    time.Duration `mapstructure:"duration"`            // 0 なら終了しない
+	Every       time.Duration `mapstructure:"every"`               // 0 なら1回のみ
+	Probability float64       `mapstructure:"probability"`         // 障害を起こすリクエストの割合。0 なら全て
+	OffsetBps   float64       `mapstructure:"offset_bps"`          // spread
+	RetryAfter  int           `mapstructure:"retry_after_seconds"` // rate_limit
+	DelayMs     int           `mapstructure:"delay_ms"`            // latency
+}
+
+// active は起動からの経過時間 elapsed でイベントが有効かを返す
+func (e Event) active(elapsed time.Duration) bool {
+	if elapsed < e.At {
+		return false
+	}
+	since := elapsed - e.At
+	if e.Every > 0 {
+		since %= e.Every
+	}
+	return e.Duration == 0 || since < e.Duration
+}
+
+func (e Event) matches(venue, asset string) bool {
+	if e.Venue != "" && e.Venue != venue {
+		return false
+	}
+	return e.Asset == "" || asset == "" || strings.EqualFold(e.Asset, asset)
+}
+
+func (e Event) String() string {
+	target := e.Venue
+	if target == "" {
+		target = "all venues"
+	}
+	if e.Asset != "" {
+		target += " " + e.Asset
+	}
+	return e.Kind + " on " + target
+}
+
+// defaultScenario はシナリオファイルを指定しない場合の台本（ランダムウォークのみ）
+func defaultScenario() Scenario {
+	return Scenario{
+		Seed:   1,
+		TickMs: 500,
+		Assets: []AssetScenario{
+			{Name: "BTC", Start: 100000, VolatilityBps: 2, HalfSpreadBps: 0.5},
+			{Name: "ETH", Start: 3500, VolatilityBps: 3, HalfSpreadBps: 1},
+			{Name: "SOL", Start: 200, VolatilityBps: 4, HalfSpreadBps: 2},
+		},
+		Venues: map[string]VenueScenario{
+			venueHyperliquid: {OffsetBps: 0, NoiseBps: 0.5, FundingRate: 0.0000125},
+			venueLighter:     {OffsetBps: -2, NoiseBps: 0.5, FundingRate: 0.00001},
+			venueAster:       {OffsetBps: 3, NoiseBps: 0.5, FundingRate: 0.00002},
+			venueDydx:        {OffsetBps: 1, NoiseBps: 0.5, FundingRate: 0.000008},
+			venueParadex:     {OffsetBps: -1, NoiseBps: 0.5, FundingRate: 0.000015},
+			venueVertex:      {OffsetBps: 2, NoiseBps: 0.5, FundingRate: 0.000005},
+			venueDrift:       {OffsetBps: -3, NoiseBps: 0.5, FundingRate: 0.00003},
+			venueBinance:     {OffsetBps: 0, NoiseBps: 0.2, FundingRate: 0.0000125},
+			venueBybit:       {OffsetBps: 0.5, NoiseBps: 0.2, FundingRate: 0.0000125},
+			venueOKX:         {OffsetBps: -0.5, NoiseBps: 0.2, FundingRate: 0.0000125},
+		},
+	}
+}
+
+// loadScenario は YAML のシナリオを読み込む。path が空なら既定の台本。
+// assets / venues を省略した場合は既定の台本のものを使う
+func loadScenario(path string) (Scenario, error) {
+	defaults := defaultScenario()
+	if path == "" {
+		return defaults, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetDefault("seed", defaults.Seed)
+	v.SetDefault("tick_ms", defaults.TickMs)
+	if err := v.ReadInConfig(); err != nil {
+		return Scenario{}, err
+	}
+	var scenario Scenario
+	if err := v.Unmarshal(&scenario); err != nil {
+		return Scenario{}, err
+	}
+	if len(scenario.Assets) == 0 {
+		scenario.Assets = defaults.Assets
+	}
+	if len(scenario.Venues) == 0 {
+		scenario.Venues = defaults.Venues
+	}
+
+	if scenario.TickMs <= 0 {
+		return Scenario{}, fmt.Errorf("tick_ms must be positive")
+	}
+	for i, a := range scenario.Assets {
+		if a.Name == "" || a.Start <= 0 {
+			return Scenario{}, fmt.Errorf("assets[%d]: name and a positive start are required", i)
+		}
+		scenario.Assets[i].Name = strings.ToUpper(a.Name)
+	}
+	for i, e := range scenario.Events {
+		switch e.Kind {
+		case eventSpread, eventOutage, eventMalformed, eventRateLimit, eventLatency:
+		default:
+			return Scenario{}, fmt.Errorf("events[%d]: unknown kind %q", i, e.Kind)
+		}
+	}
+	return scenario, nil
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// streamBookLevels は WebSocket で配信する板の段数
+const streamBookLevels = 5
+
+// venueStream は取引所ごとの WebSocket の購読・配信の形式
+type venueStream interface {
+	// handle はクライアントからのメッセージを解釈し、購読する銘柄と応答（不要なら nil）を返す
+	handle(msg []byte) (assets []string, reply interface{})
+	// update は銘柄の配信メッセージを返す。扱っていない銘柄は nil
+	update(m *market, sess *streamSession, asset string) interface{}
+}
+
+// streamSession は接続ごとの購読状態
+type streamSession struct {
+	mu     sync.Mutex
+	assets map[string]bool
+	books  map[string]map[string]map[float64]bool // 銘柄 → bids/asks → 前回配信した価格（差分配信用）
+}
+
+func (sess *streamSession) subscribed() []string {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	assets := make([]string, 0, len(sess.assets))
+	for a := range sess.assets {
+		assets = append(assets, a)
+	}
+	return assets
+}
+
+// streamHandler はティックごとに購読中の銘柄を配信する。outage / rate_limit の間は接続を拒否し、
+// outage が始まったら切断、malformed の間は壊れた JSON を送る
+func (s *server) streamHandler(venue string, vs venueStream) http.Handler {
+	ws := websocket.Handler(func(conn *websocket.Conn) {
+		defer conn.Close()
+		sess := &streamSession{
+			assets: make(map[string]bool),
+			books:  make(map[string]map[string]map[float64]bool),
+		}
+
+		var sendMu sync.Mutex
+		send := func(v interface{}) error {
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			return websocket.JSON.Send(conn, v)
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				var msg []byte
+				if err := websocket.Message.Receive(conn, &msg); err != nil {
+					return
+				}
+				assets, reply := vs.handle(msg)
+				sess.mu.Lock()
+				for _, a := range assets {
+					sess.assets[a] = true
+				}
+				sess.mu.Unlock()
+				if reply != nil {
+					if err := send(reply); err != nil {
+						return
+					}
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(s.market.tick())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			if e := s.market.fault(venue); e != nil {
+				switch e.Kind {
+				case eventOutage:
+					log.Printf("[mockdex] %s stream closed by outage", venue)
+					return
+				case eventMalformed:
+					sendMu.Lock()
+					err := websocket.Message.Send(conn, malformedBody)
+					sendMu.Unlock()
+					if err != nil {
+						return
+					}
+					continue
+				}
+			}
+
+			for _, asset := range sess.subscribed() {
+				msg := vs.update(s.market, sess, asset)
+				if msg == nil {
+					continue
+				}
+				if err := send(msg); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e := s.market.fault(venue); e != nil {
+			switch e.Kind {
+			case eventOutage:
+				http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+				return
+			case eventRateLimit:
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+				return
+			}
+		}
+		ws.ServeHTTP(w, r)
+	})
+}
+
+// --- Hyperliquid ---
+
+type hyperliquidStream struct{}
+
+func (hyperliquidStream) handle(msg []byte) ([]string, interface{}) {
+	var req struct {
+		Method       string `json:"method"`
+		Subscription struct {
+			Type string `json:"type"`
+			Coin string `json:"coin"`
+		} `json:"subscription"`
+	}
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return nil, nil
+	}
+	switch req.Method {
+	case "ping":
+		return nil, map[string]string{"channel": "pong"}
+	case "subscribe":
+		if req.Subscription.Type != "l2Book" {
+			return nil, nil
+		}
+		return []string{req.Subscription.Coin}, map[string]interface{}{
+			"channel": "subscriptionResponse",
+			"data":    req,
+		}
+	}
+	return nil, nil
+}
+
+func (hyperliquidStream) update(m *market, sess *streamSession, asset string) interface{} {
+	book, ok := hyperliquidBook(m, asset)
+	if !ok {
+		return nil
+	}
+	return map[string]interface{}{"channel": "l2Book", "data": book}
+}
+
+// --- Lighter ---
+
+type lighterStream struct {
+	s *server
+}
+
+type lighterWSLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+func (ls lighterStream) handle(msg []byte) ([]string, interface{}) {
+	var req struct {
+		Type    string `json:"type"`
+		Channel string `json:"channel"`
+	}
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return nil, nil
+	}
+	switch req.Type {
+	case "ping":
+		return nil, map[string]string{"type": "pong"}
+	case "subscribe":
+		// channel は "order_book/1" の形式
+		id, err := strconv.Atoi(strings.TrimPrefix(req.Channel, "order_book/"))
+		if err != nil {
+			return nil, nil
+		}
+		if asset, ok := ls.s.lighterAssets[id]; ok {
+			return []string{asset}, nil
+		}
+	}
+	return nil, nil
+}
+
+// update は購読直後にスナップショット、以降は前回からの差分（消えた価格は数量 0）を送る
+func (ls lighterStream) update(m *market, sess *streamSession, asset string) interface{} {
+	bids, asks, _, ok := m.book(venueLighter, asset, streamBookLevels)
+	if !ok {
+		return nil
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	prev, sent := sess.books[asset]
+	if !sent {
+		prev = map[string]map[float64]bool{"bids": {}, "asks": {}}
+	}
+	diff := func(side string, levels []level) []lighterWSLevel {
+		next := make(map[float64]bool, len(levels))
+		result := make([]lighterWSLevel, 0, len(levels))
+		for _, l := range levels {
+			next[l.Price] = true
+			result = append(result, lighterWSLevel{Price: formatFloat(l.Price), Size: formatFloat(l.Size)})
+		}
+		for px := range prev[side] {
+			if !next[px] {
+				result = append(result, lighterWSLevel{Price: formatFloat(px), Size: "0"})
+			}
+		}
+		prev[side] = next
+		return result
+	}
+	orderBook := map[string]interface{}{
+		"bids": diff("bids", bids),
+		"asks": diff("asks", asks),
+	}
+	sess.books[asset] = prev
+
+	msgType := "update/order_book"
+	if !sent {
+		msgType = "subscribed/order_book"
+	}
+	return map[string]interface{}{
+		"type":       msgType,
+		"channel":    fmt.Sprintf("order_book:%d", ls.s.lighterIDs[asset]),
+		"order_book": orderBook,
+	}
+}
+
+// --- Aster / Binance ---
+
+// asterStream は Binance 形式の bookTicker ストリーム（Aster と Binance で共通）
+type asterStream struct {
+	venue string
+}
+
+func (asterStream) handle(msg []byte) ([]string, interface{}) {
+	var req struct {
+		Method string   `json:"method"`
+		Params []string `json:"params"`
+		ID     int      `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &req); err != nil || req.Method != "SUBSCRIBE" {
+		return nil, nil
+	}
+	// params は "btcusdt@bookTicker" の形式
+	var assets []string
+	for _, p := range req.Params {
+		symbol, stream, _ := strings.Cut(p, "@")
+		if stream == "bookTicker" {
+			assets = append(assets, asterAsset(symbol))
+		}
+	}
+	return assets, map[string]interface{}{"result": nil, "id": req.ID}
+}
+
+func (as asterStream) update(m *market, sess *streamSession, asset string) interface{} {
+	bids, asks, q, ok := m.book(as.venue, asset, 1)
+	if !ok {
+		return nil
+	}
+	return map[string]interface{}{
+		"e": "bookTicker",
+		"s": asset + "USDT",
+		"b": formatFloat(bids[0].Price),
+		"B": formatFloat(bids[0].Size),
+		"a": formatFloat(asks[0].Price),
+		"A": formatFloat(asks[0].Size),
+		"T": q.Ts.UnixMilli(),
+	}
+}
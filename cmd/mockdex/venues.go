@@ -0,0 +1,888 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	venueHyperliquid = "hyperliquid"
+	venueLighter     = "lighter"
+	venueAster       = "aster"
+	venueDydx        = "dydx"
+	venueParadex     = "paradex"
+	venueVertex      = "vertex"
+	venueDrift       = "drift"
+	venueBinance     = "binance"
+	venueBybit       = "bybit"
+	venueOKX         = "okx"
+
+	// fundingIntervalHours は Lighter / Aster / Paradex が返す Funding Rate の期間
+	fundingIntervalHours = 8
+	// vertexFundingPeriodHours は Vertex の archive が返す Funding Rate の期間
+	vertexFundingPeriodHours = 24
+	// hyperliquidBookLevels は Hyperliquid の l2Book の段数
+	hyperliquidBookLevels = 20
+	// malformedBody は malformed イベントで返す途中で切れた JSON
+	malformedBody = `{"coin":"BTC","levels":[[{"px":"1`
+)
+
+// lighterKnownMarketIDs は本番と同じ market_id。それ以外の銘柄は lighterExtraMarketID から順に振る
+var lighterKnownMarketIDs = map[string]int{"ETH": 0, "BTC": 1, "SOL": 2}
+
+const lighterExtraMarketID = 100
+
+// vertexKnownProductIDs は本番と同じ product_id。それ以外の銘柄は vertexExtraProductID から順に振る
+var vertexKnownProductIDs = map[string]int{"BTC": 2, "ETH": 4}
+
+const vertexExtraProductID = 100
+
+// server は取引所の REST / WebSocket API を /hyperliquid, /lighter, /aster などの下で模倣する
+type server struct {
+	market        *market
+	lighterIDs    map[string]int // 銘柄 → market_id
+	lighterAssets map[int]string // market_id → 銘柄
+	vertexIDs     map[string]int // 銘柄 → product_id
+	vertexAssets  map[int]string // product_id → 銘柄
+}
+
+func newServer(m *market) *server {
+	s := &server{
+		market:        m,
+		lighterIDs:    make(map[string]int),
+		lighterAssets: make(map[int]string),
+		vertexIDs:     make(map[string]int),
+		vertexAssets:  make(map[int]string),
+	}
+	for i, a := range m.scenario.Assets {
+		id, ok := lighterKnownMarketIDs[a.Name]
+		if !ok {
+			id = lighterExtraMarketID + i
+		}
+		s.lighterIDs[a.Name] = id
+		s.lighterAssets[id] = a.Name
+
+		id, ok = vertexKnownProductIDs[a.Name]
+		if !ok {
+			id = vertexExtraProductID + 2*i
+		}
+		s.vertexIDs[a.Name] = id
+		s.vertexAssets[id] = a.Name
+	}
+	return s
+}
+
+func (s *server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /hyperliquid/info", s.withFaults(venueHyperliquid, s.hyperliquidInfo))
+	mux.Handle("/hyperliquid/ws", s.streamHandler(venueHyperliquid, hyperliquidStream{}))
+
+	mux.HandleFunc("GET /lighter/api/v1/orderBookOrders", s.withFaults(venueLighter, s.lighterOrderBookOrders))
+	mux.HandleFunc("GET /lighter/api/v1/funding-rates", s.withFaults(venueLighter, s.lighterFundingRates))
+	mux.HandleFunc("GET /lighter/api/v1/orderBooks", s.withFaults(venueLighter, s.lighterOrderBooks))
+	mux.HandleFunc("GET /lighter/api/v1/orderBookDetails", s.withFaults(venueLighter, s.lighterOrderBookDetails))
+	mux.Handle("/lighter/stream", s.streamHandler(venueLighter, lighterStream{s}))
+
+	for _, venue := range []string{venueAster, venueBinance} {
+		mux.HandleFunc("GET /"+venue+"/fapi/v1/ticker/bookTicker", s.withFaults(venue, s.asterBookTicker(venue)))
+		mux.HandleFunc("GET /"+venue+"/fapi/v1/depth", s.withFaults(venue, s.asterDepth(venue)))
+		mux.HandleFunc("GET /"+venue+"/fapi/v1/premiumIndex", s.withFaults(venue, s.asterPremiumIndex(venue)))
+		mux.HandleFunc("GET /"+venue+"/fapi/v1/ticker/24hr", s.withFaults(venue, s.asterTicker24hr(venue)))
+		mux.HandleFunc("GET /"+venue+"/fapi/v1/openInterest", s.withFaults(venue, s.asterOpenInterest(venue)))
+		mux.Handle("/"+venue+"/ws", s.streamHandler(venue, asterStream{venue}))
+	}
+
+	mux.HandleFunc("GET /dydx/orderbooks/perpetualMarket/{ticker}", s.withFaults(venueDydx, s.dydxOrderBook))
+	mux.HandleFunc("GET /dydx/perpetualMarkets", s.withFaults(venueDydx, s.dydxPerpetualMarkets))
+
+	mux.HandleFunc("GET /paradex/bbo/{market}", s.withFaults(venueParadex, s.paradexBBO))
+	mux.HandleFunc("GET /paradex/orderbook/{market}", s.withFaults(venueParadex, s.paradexOrderBook))
+	mux.HandleFunc("GET /paradex/markets/summary", s.withFaults(venueParadex, s.paradexMarketsSummary))
+
+	mux.HandleFunc("GET /vertex/query", s.withFaults(venueVertex, s.vertexQuery))
+	mux.HandleFunc("POST /vertex/archive", s.withFaults(venueVertex, s.vertexArchive))
+
+	mux.HandleFunc("GET /drift/l2", s.withFaults(venueDrift, s.driftL2))
+	mux.HandleFunc("GET /drift/data/fundingRates", s.withFaults(venueDrift, s.driftFundingRates))
+
+	mux.HandleFunc("GET /bybit/v5/market/tickers", s.withFaults(venueBybit, s.bybitTickers))
+	mux.HandleFunc("GET /bybit/v5/market/orderbook", s.withFaults(venueBybit, s.bybitOrderBook))
+
+	mux.HandleFunc("GET /okx/api/v5/market/ticker", s.withFaults(venueOKX, s.okxTicker))
+	mux.HandleFunc("GET /okx/api/v5/market/books", s.withFaults(venueOKX, s.okxBooks))
+	mux.HandleFunc("GET /okx/api/v5/public/funding-rate", s.withFaults(venueOKX, s.okxFundingRate))
+	mux.HandleFunc("GET /okx/api/v5/public/instruments", s.withFaults(venueOKX, s.okxInstruments))
+
+	return mux
+}
+
+// withFaults は台本の障害を REST の応答に反映する
+func (s *server) withFaults(venue string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e := s.market.fault(venue)
+		if e == nil {
+			next(w, r)
+			return
+		}
+
+		switch e.Kind {
+		case eventLatency:
+			select {
+			case <-time.After(time.Duration(e.DelayMs) * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+			next(w, r)
+		case eventOutage:
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		case eventRateLimit:
+			if e.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(e.RetryAfter))
+			}
+			writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": "too many requests"})
+		case eventMalformed:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(malformedBody))
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatFixed は f を 10^decimals 倍した整数の文字列にする（Vertex / Drift の固定小数点）
+func formatFixed(f float64, decimals int) string {
+	return strconv.FormatFloat(math.Round(f*math.Pow10(decimals)), 'f', 0, 64)
+}
+
+// toPairs は板を ["価格", "数量"] 形式の配列にする
+func toPairs(levels []level) [][]string {
+	result := make([][]string, 0, len(levels))
+	for _, l := range levels {
+		result = append(result, []string{formatFloat(l.Price), formatFloat(l.Size)})
+	}
+	return result
+}
+
+// --- Hyperliquid ---
+
+type hyperliquidLevel struct {
+	Px string `json:"px"`
+	Sz string `json:"sz"`
+	N  int    `json:"n"`
+}
+
+type hyperliquidL2Book struct {
+	Coin   string               `json:"coin"`
+	Time   int64                `json:"time"`
+	Levels [][]hyperliquidLevel `json:"levels"`
+}
+
+func (s *server) hyperliquidInfo(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Type string `json:"type"`
+		Coin string `json:"coin"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	switch req.Type {
+	case "l2Book":
+		book, ok := hyperliquidBook(s.market, req.Coin)
+		if !ok {
+			writeJSON(w, http.StatusOK, nil) // 本番も未知の coin には null を返す
+			return
+		}
+		writeJSON(w, http.StatusOK, book)
+	case "metaAndAssetCtxs":
+		type asset struct {
+			Name string `json:"name"`
+		}
+		type assetCtx struct {
+			Funding      string `json:"funding"`
+			MarkPx       string `json:"markPx"`
+			OraclePx     string `json:"oraclePx"`
+			OpenInterest string `json:"openInterest"`
+			DayNtlVlm    string `json:"dayNtlVlm"`
+		}
+		universe := make([]asset, 0, len(s.market.scenario.Assets))
+		ctxs := make([]assetCtx, 0, len(s.market.scenario.Assets))
+		for _, a := range s.market.scenario.Assets {
+			q, _ := s.market.quote(venueHyperliquid, a.Name)
+			mid := (q.Bid + q.Ask) / 2
+			universe = append(universe, asset{Name: a.Name})
+			ctxs = append(ctxs, assetCtx{
+				Funding:      formatFloat(s.market.fundingRate(venueHyperliquid)),
+				MarkPx:       formatFloat(mid),
+				OraclePx:     formatFloat(mid),
+				OpenInterest: "1000",
+				DayNtlVlm:    formatFloat(mid * 10000),
+			})
+		}
+		writeJSON(w, http.StatusOK, []interface{}{map[string]interface{}{"universe": universe}, ctxs})
+	default:
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": "unsupported type: " + req.Type})
+	}
+}
+
+func hyperliquidBook(m *market, coin string) (hyperliquidL2Book, bool) {
+	bids, asks, q, ok := m.book(venueHyperliquid, coin, hyperliquidBookLevels)
+	if !ok {
+		return hyperliquidL2Book{}, false
+	}
+	toLevels := func(levels []level) []hyperliquidLevel {
+		result := make([]hyperliquidLevel, 0, len(levels))
+		for _, l := range levels {
+			result = append(result, hyperliquidLevel{Px: formatFloat(l.Price), Sz: formatFloat(l.Size), N: 1})
+		}
+		return result
+	}
+	return hyperliquidL2Book{
+		Coin:   coin,
+		Time:   q.Ts.UnixMilli(),
+		Levels: [][]hyperliquidLevel{toLevels(bids), toLevels(asks)},
+	}, true
+}
+
+// --- Lighter ---
+
+type lighterOrder struct {
+	OrderID         string `json:"order_id"`
+	RemainingAmount string `json:"remaining_base_amount"`
+	Price           string `json:"price"`
+}
+
+func (s *server) lighterAsset(r *http.Request) (string, bool) {
+	id, err := strconv.Atoi(r.URL.Query().Get("market_id"))
+	if err != nil {
+		return "", false
+	}
+	asset, ok := s.lighterAssets[id]
+	return asset, ok
+}
+
+func (s *server) lighterOrderBookOrders(w http.ResponseWriter, r *http.Request) {
+	asset, ok := s.lighterAsset(r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": 21100, "message": "market not found"})
+		return
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 1
+	}
+
+	bids, asks, _, _ := s.market.book(venueLighter, asset, limit)
+	toOrders := func(levels []level) []lighterOrder {
+		result := make([]lighterOrder, 0, len(levels))
+		for i, l := range levels {
+			result = append(result, lighterOrder{
+				OrderID:         strconv.Itoa(i + 1),
+				RemainingAmount: formatFloat(l.Size),
+				Price:           formatFloat(l.Price),
+			})
+		}
+		return result
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code":       200,
+		"total_asks": len(asks),
+		"asks":       toOrders(asks),
+		"total_bids": len(bids),
+		"bids":       toOrders(bids),
+	})
+}
+
+func (s *server) lighterFundingRates(w http.ResponseWriter, r *http.Request) {
+	type fundingRate struct {
+		MarketID int     `json:"market_id"`
+		Exchange string  `json:"exchange"`
+		Symbol   string  `json:"symbol"`
+		Rate     float64 `json:"rate"`
+	}
+	rate := s.market.fundingRate(venueLighter) * fundingIntervalHours
+	var rates []fundingRate
+	for _, a := range s.market.scenario.Assets {
+		// 本番と同様に他取引所のレートも混ぜる
+		rates = append(rates,
+			fundingRate{MarketID: s.lighterIDs[a.Name], Exchange: "binance", Symbol: a.Name, Rate: rate * 2},
+			fundingRate{MarketID: s.lighterIDs[a.Name], Exchange: venueLighter, Symbol: a.Name, Rate: rate},
+		)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "funding_rates": rates})
+}
+
+func (s *server) lighterOrderBooks(w http.ResponseWriter, r *http.Request) {
+	type orderBook struct {
+		Symbol   string `json:"symbol"`
+		MarketID int    `json:"market_id"`
+	}
+	books := make([]orderBook, 0, len(s.market.scenario.Assets))
+	for _, a := range s.market.scenario.Assets {
+		books = append(books, orderBook{Symbol: a.Name, MarketID: s.lighterIDs[a.Name]})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "order_books": books})
+}
+
+func (s *server) lighterOrderBookDetails(w http.ResponseWriter, r *http.Request) {
+	asset, ok := s.lighterAsset(r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": 21100, "message": "market not found"})
+		return
+	}
+	q, _ := s.market.quote(venueLighter, asset)
+	mid := (q.Bid + q.Ask) / 2
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code": 200,
+		"order_book_details": []map[string]interface{}{{
+			"symbol":                   asset,
+			"market_id":                s.lighterIDs[asset],
+			"last_trade_price":         mid,
+			"daily_quote_token_volume": mid * 10000,
+			"open_interest":            1000,
+		}},
+	})
+}
+
+// --- Aster / Binance（同じ API 形式） ---
+
+// asterAsset は BTCUSDT 形式のシンボルを銘柄に変換する
+func asterAsset(symbol string) string {
+	return strings.TrimSuffix(strings.ToUpper(symbol), "USDT")
+}
+
+func writeAsterInvalidSymbol(w http.ResponseWriter) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": -1121, "msg": "Invalid symbol."})
+}
+
+func (s *server) asterBookTicker(venue string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		bids, asks, q, ok := s.market.book(venue, asterAsset(symbol), 1)
+		if !ok {
+			writeAsterInvalidSymbol(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"symbol":   symbol,
+			"bidPrice": formatFloat(bids[0].Price),
+			"bidQty":   formatFloat(bids[0].Size),
+			"askPrice": formatFloat(asks[0].Price),
+			"askQty":   formatFloat(asks[0].Size),
+			"time":     q.Ts.UnixMilli(),
+		})
+	}
+}
+
+func (s *server) asterDepth(venue string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit < 1 {
+			limit = 500
+		}
+		bids, asks, q, ok := s.market.book(venue, asterAsset(symbol), limit)
+		if !ok {
+			writeAsterInvalidSymbol(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"lastUpdateId": q.Ts.UnixNano(),
+			"E":            q.Ts.UnixMilli(),
+			"T":            q.Ts.UnixMilli(),
+			"bids":         toPairs(bids),
+			"asks":         toPairs(asks),
+		})
+	}
+}
+
+func (s *server) asterPremiumIndex(venue string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		q, ok := s.market.quote(venue, asterAsset(symbol))
+		if !ok {
+			writeAsterInvalidSymbol(w)
+			return
+		}
+		mid := (q.Bid + q.Ask) / 2
+		nextFunding := q.Ts.Truncate(fundingIntervalHours * time.Hour).Add(fundingIntervalHours * time.Hour)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"symbol":          symbol,
+			"markPrice":       formatFloat(mid),
+			"indexPrice":      formatFloat(mid),
+			"lastFundingRate": formatFloat(s.market.fundingRate(venue) * fundingIntervalHours),
+			"nextFundingTime": nextFunding.UnixMilli(),
+			"time":            q.Ts.UnixMilli(),
+		})
+	}
+}
+
+func (s *server) asterTicker24hr(venue string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		q, ok := s.market.quote(venue, asterAsset(symbol))
+		if !ok {
+			writeAsterInvalidSymbol(w)
+			return
+		}
+		mid := (q.Bid + q.Ask) / 2
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"symbol":      symbol,
+			"lastPrice":   formatFloat(mid),
+			"volume":      "10000",
+			"quoteVolume": formatFloat(mid * 10000),
+			"closeTime":   q.Ts.UnixMilli(),
+		})
+	}
+}
+
+func (s *server) asterOpenInterest(venue string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		q, ok := s.market.quote(venue, asterAsset(symbol))
+		if !ok {
+			writeAsterInvalidSymbol(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"symbol":       symbol,
+			"openInterest": "1000",
+			"time":         q.Ts.UnixMilli(),
+		})
+	}
+}
+
+// --- dYdX v4 ---
+
+// dydxAsset は BTC-USD 形式のシンボルを銘柄に変換する
+func dydxAsset(ticker string) string {
+	return strings.TrimSuffix(strings.ToUpper(ticker), "-USD")
+}
+
+func (s *server) dydxOrderBook(w http.ResponseWriter, r *http.Request) {
+	bids, asks, _, ok := s.market.book(venueDydx, dydxAsset(r.PathValue("ticker")), 20)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []map[string]string{{"msg": "ticker not found"}}})
+		return
+	}
+	toLevels := func(levels []level) []map[string]string {
+		result := make([]map[string]string, 0, len(levels))
+		for _, l := range levels {
+			result = append(result, map[string]string{"price": formatFloat(l.Price), "size": formatFloat(l.Size)})
+		}
+		return result
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"bids": toLevels(bids), "asks": toLevels(asks)})
+}
+
+func (s *server) dydxPerpetualMarkets(w http.ResponseWriter, r *http.Request) {
+	ticker := r.URL.Query().Get("ticker")
+	markets := make(map[string]interface{})
+	for _, a := range s.market.scenario.Assets {
+		t := a.Name + "-USD"
+		if ticker != "" && ticker != t {
+			continue
+		}
+		q, _ := s.market.quote(venueDydx, a.Name)
+		markets[t] = map[string]interface{}{
+			"ticker":          t,
+			"status":          "ACTIVE",
+			"oraclePrice":     formatFloat((q.Bid + q.Ask) / 2),
+			"nextFundingRate": formatFloat(s.market.fundingRate(venueDydx)),
+			"openInterest":    "1000",
+			"volume24H":       formatFloat((q.Bid + q.Ask) / 2 * 10000),
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"markets": markets})
+}
+
+// --- Paradex ---
+
+// paradexAsset は BTC-USD-PERP 形式のシンボルを銘柄に変換する
+func paradexAsset(market string) string {
+	return strings.TrimSuffix(strings.ToUpper(market), "-USD-PERP")
+}
+
+func writeParadexMarketNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "MARKET_NOT_FOUND", "message": "market not found"})
+}
+
+func (s *server) paradexBBO(w http.ResponseWriter, r *http.Request) {
+	market := r.PathValue("market")
+	bids, asks, q, ok := s.market.book(venueParadex, paradexAsset(market), 1)
+	if !ok {
+		writeParadexMarketNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"market":          market,
+		"bid":             formatFloat(bids[0].Price),
+		"bid_size":        formatFloat(bids[0].Size),
+		"ask":             formatFloat(asks[0].Price),
+		"ask_size":        formatFloat(asks[0].Size),
+		"last_updated_at": q.Ts.UnixMilli(),
+		"seq_no":          q.Ts.UnixNano(),
+	})
+}
+
+func (s *server) paradexOrderBook(w http.ResponseWriter, r *http.Request) {
+	market := r.PathValue("market")
+	depth, err := strconv.Atoi(r.URL.Query().Get("depth"))
+	if err != nil || depth < 1 {
+		depth = 20
+	}
+	bids, asks, q, ok := s.market.book(venueParadex, paradexAsset(market), depth)
+	if !ok {
+		writeParadexMarketNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"market":          market,
+		"bids":            toPairs(bids),
+		"asks":            toPairs(asks),
+		"last_updated_at": q.Ts.UnixMilli(),
+		"seq_no":          q.Ts.UnixNano(),
+	})
+}
+
+func (s *server) paradexMarketsSummary(w http.ResponseWriter, r *http.Request) {
+	market := r.URL.Query().Get("market")
+	results := []map[string]interface{}{}
+	for _, a := range s.market.scenario.Assets {
+		symbol := a.Name + "-USD-PERP"
+		if market != "" && market != "ALL" && market != symbol {
+			continue
+		}
+		q, _ := s.market.quote(venueParadex, a.Name)
+		mid := (q.Bid + q.Ask) / 2
+		results = append(results, map[string]interface{}{
+			"symbol":           symbol,
+			"mark_price":       formatFloat(mid),
+			"underlying_price": formatFloat(mid),
+			"funding_rate":     formatFloat(s.market.fundingRate(venueParadex) * fundingIntervalHours),
+			"open_interest":    "1000",
+			"volume_24h":       formatFloat(mid * 10000),
+			"created_at":       q.Ts.UnixMilli(),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+// --- Vertex ---
+
+func writeVertexQuery(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "success", "data": data})
+}
+
+func writeVertexError(w http.ResponseWriter, msg string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "failure", "error": msg, "error_code": 2000})
+}
+
+func (s *server) vertexAsset(productID string) (string, bool) {
+	id, err := strconv.Atoi(productID)
+	if err != nil {
+		return "", false
+	}
+	asset, ok := s.vertexAssets[id]
+	return asset, ok
+}
+
+func (s *server) vertexQuery(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch query.Get("type") {
+	case "symbols":
+		symbols := make(map[string]interface{})
+		for _, a := range s.market.scenario.Assets {
+			symbols[a.Name+"-PERP"] = map[string]interface{}{"type": "perp", "product_id": s.vertexIDs[a.Name], "symbol": a.Name + "-PERP"}
+		}
+		writeVertexQuery(w, map[string]interface{}{"symbols": symbols})
+	case "market_price":
+		asset, ok := s.vertexAsset(query.Get("product_id"))
+		if !ok {
+			writeVertexError(w, "invalid product_id")
+			return
+		}
+		q, _ := s.market.quote(venueVertex, asset)
+		writeVertexQuery(w, map[string]interface{}{
+			"product_id": s.vertexIDs[asset],
+			"bid_x18":    formatFixed(q.Bid, 18),
+			"ask_x18":    formatFixed(q.Ask, 18),
+		})
+	case "market_liquidity":
+		asset, ok := s.vertexAsset(query.Get("product_id"))
+		if !ok {
+			writeVertexError(w, "invalid product_id")
+			return
+		}
+		depth, err := strconv.Atoi(query.Get("depth"))
+		if err != nil || depth < 1 {
+			depth = 20
+		}
+		bids, asks, q, _ := s.market.book(venueVertex, asset, depth)
+		toX18Pairs := func(levels []level) [][]string {
+			result := make([][]string, 0, len(levels))
+			for _, l := range levels {
+				result = append(result, []string{formatFixed(l.Price, 18), formatFixed(l.Size, 18)})
+			}
+			return result
+		}
+		writeVertexQuery(w, map[string]interface{}{
+			"bids":      toX18Pairs(bids),
+			"asks":      toX18Pairs(asks),
+			"timestamp": strconv.FormatInt(q.Ts.UnixNano(), 10),
+		})
+	default:
+		writeVertexError(w, "unsupported query type: "+query.Get("type"))
+	}
+}
+
+func (s *server) vertexArchive(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FundingRate *struct {
+			ProductID int `json:"product_id"`
+		} `json:"funding_rate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.FundingRate == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+	if _, ok := s.vertexAssets[req.FundingRate.ProductID]; !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid product_id"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"product_id":       req.FundingRate.ProductID,
+		"funding_rate_x18": formatFixed(s.market.fundingRate(venueVertex)*vertexFundingPeriodHours, 18),
+		"update_time":      strconv.FormatInt(time.Now().Unix(), 10),
+	})
+}
+
+// --- Drift ---
+
+// driftAsset は BTC-PERP 形式のシンボルを銘柄に変換する
+func driftAsset(marketName string) string {
+	return strings.TrimSuffix(strings.ToUpper(marketName), "-PERP")
+}
+
+func (s *server) driftL2(w http.ResponseWriter, r *http.Request) {
+	marketName := r.URL.Query().Get("marketName")
+	depth, err := strconv.Atoi(r.URL.Query().Get("depth"))
+	if err != nil || depth < 1 {
+		depth = 10
+	}
+	bids, asks, q, ok := s.market.book(venueDrift, driftAsset(marketName), depth)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid marketName"})
+		return
+	}
+	toLevels := func(levels []level) []map[string]string {
+		result := make([]map[string]string, 0, len(levels))
+		for _, l := range levels {
+			result = append(result, map[string]string{"price": formatFixed(l.Price, 6), "size": formatFixed(l.Size, 9)})
+		}
+		return result
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"marketName": marketName,
+		"bids":       toLevels(bids),
+		"asks":       toLevels(asks),
+		"slot":       q.Ts.UnixMilli() / 400,
+		"ts":         q.Ts.UnixMilli(),
+	})
+}
+
+func (s *server) driftFundingRates(w http.ResponseWriter, r *http.Request) {
+	marketName := r.URL.Query().Get("marketName")
+	q, ok := s.market.quote(venueDrift, driftAsset(marketName))
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid marketName"})
+		return
+	}
+	mid := (q.Bid + q.Ask) / 2
+	rate := s.market.fundingRate(venueDrift)
+
+	// 直近の精算履歴を古い順に返す。fundingRate は1時間・1枚あたりの USD
+	var rates []map[string]string
+	for i := 3; i >= 1; i-- {
+		ts := q.Ts.Truncate(time.Hour).Add(-time.Duration(i-1) * time.Hour)
+		rates = append(rates, map[string]string{
+			"ts":              strconv.FormatInt(ts.Unix(), 10),
+			"fundingRate":     formatFixed(rate*mid, 9),
+			"oraclePriceTwap": formatFixed(mid, 6),
+			"markPriceTwap":   formatFixed(mid, 6),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"fundingRates": rates})
+}
+
+// --- Bybit ---
+
+func writeBybit(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"retCode": 0,
+		"retMsg":  "OK",
+		"result":  result,
+		"time":    time.Now().UnixMilli(),
+	})
+}
+
+func writeBybitInvalidSymbol(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"retCode": 10001, "retMsg": "params error: symbol invalid", "result": map[string]interface{}{}})
+}
+
+func (s *server) bybitTickers(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	bids, asks, q, ok := s.market.book(venueBybit, asterAsset(symbol), 1)
+	if !ok {
+		writeBybitInvalidSymbol(w)
+		return
+	}
+	mid := (q.Bid + q.Ask) / 2
+	writeBybit(w, map[string]interface{}{
+		"category": "linear",
+		"list": []map[string]string{{
+			"symbol":       symbol,
+			"bid1Price":    formatFloat(bids[0].Price),
+			"bid1Size":     formatFloat(bids[0].Size),
+			"ask1Price":    formatFloat(asks[0].Price),
+			"ask1Size":     formatFloat(asks[0].Size),
+			"markPrice":    formatFloat(mid),
+			"indexPrice":   formatFloat(mid),
+			"fundingRate":  formatFloat(s.market.fundingRate(venueBybit) * fundingIntervalHours),
+			"openInterest": "1000",
+			"turnover24h":  formatFloat(mid * 10000),
+		}},
+	})
+}
+
+func (s *server) bybitOrderBook(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 25
+	}
+	bids, asks, q, ok := s.market.book(venueBybit, asterAsset(symbol), limit)
+	if !ok {
+		writeBybitInvalidSymbol(w)
+		return
+	}
+	writeBybit(w, map[string]interface{}{
+		"s":  symbol,
+		"b":  toPairs(bids),
+		"a":  toPairs(asks),
+		"ts": q.Ts.UnixMilli(),
+		"u":  q.Ts.UnixNano(),
+	})
+}
+
+// --- OKX ---
+
+// okxContractValue は全銘柄共通の1契約あたりの基軸通貨の数量
+const okxContractValue = 0.01
+
+// okxAsset は BTC-USDT-SWAP 形式のシンボルを銘柄に変換する
+func okxAsset(instID string) string {
+	return strings.TrimSuffix(strings.ToUpper(instID), "-USDT-SWAP")
+}
+
+func writeOKX(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": "0", "msg": "", "data": data})
+}
+
+func writeOKXInvalidInstrument(w http.ResponseWriter) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": "51001", "msg": "Instrument ID does not exist", "data": []interface{}{}})
+}
+
+func (s *server) okxTicker(w http.ResponseWriter, r *http.Request) {
+	instID := r.URL.Query().Get("instId")
+	bids, asks, q, ok := s.market.book(venueOKX, okxAsset(instID), 1)
+	if !ok {
+		writeOKXInvalidInstrument(w)
+		return
+	}
+	writeOKX(w, []map[string]string{{
+		"instId": instID,
+		"bidPx":  formatFloat(bids[0].Price),
+		"bidSz":  formatFloat(math.Round(bids[0].Size / okxContractValue)),
+		"askPx":  formatFloat(asks[0].Price),
+		"askSz":  formatFloat(math.Round(asks[0].Size / okxContractValue)),
+		"ts":     strconv.FormatInt(q.Ts.UnixMilli(), 10),
+	}})
+}
+
+func (s *server) okxBooks(w http.ResponseWriter, r *http.Request) {
+	instID := r.URL.Query().Get("instId")
+	sz, err := strconv.Atoi(r.URL.Query().Get("sz"))
+	if err != nil || sz < 1 {
+		sz = 1
+	}
+	bids, asks, q, ok := s.market.book(venueOKX, okxAsset(instID), sz)
+	if !ok {
+		writeOKXInvalidInstrument(w)
+		return
+	}
+	// 各段は [価格, 契約数, "0", 注文数]
+	toLevels := func(levels []level) [][]string {
+		result := make([][]string, 0, len(levels))
+		for _, l := range levels {
+			result = append(result, []string{formatFloat(l.Price), formatFloat(math.Round(l.Size / okxContractValue)), "0", "1"})
+		}
+		return result
+	}
+	writeOKX(w, []map[string]interface{}{{
+		"asks": toLevels(asks),
+		"bids": toLevels(bids),
+		"ts":   strconv.FormatInt(q.Ts.UnixMilli(), 10),
+	}})
+}
+
+func (s *server) okxFundingRate(w http.ResponseWriter, r *http.Request) {
+	instID := r.URL.Query().Get("instId")
+	q, ok := s.market.quote(venueOKX, okxAsset(instID))
+	if !ok {
+		writeOKXInvalidInstrument(w)
+		return
+	}
+	nextFunding := q.Ts.Truncate(fundingIntervalHours * time.Hour).Add(fundingIntervalHours * time.Hour)
+	writeOKX(w, []map[string]string{{
+		"instId":      instID,
+		"fundingRate": formatFloat(s.market.fundingRate(venueOKX) * fundingIntervalHours),
+		"fundingTime": strconv.FormatInt(nextFunding.UnixMilli(), 10),
+	}})
+}
+
+func (s *server) okxInstruments(w http.ResponseWriter, r *http.Request) {
+	instID := r.URL.Query().Get("instId")
+	var instruments []map[string]string
+	for _, a := range s.market.scenario.Assets {
+		id := a.Name + "-USDT-SWAP"
+		if instID != "" && instID != id {
+			continue
+		}
+		instruments = append(instruments, map[string]string{
+			"instId":   id,
+			"instType": "SWAP",
+			"ctVal":    formatFloat(okxContractValue),
+			"ctValCcy": a.Name,
+		})
+	}
+	if len(instruments) == 0 {
+		writeOKXInvalidInstrument(w)
+		return
+	}
+	writeOKX(w, instruments)
+}
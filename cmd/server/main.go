@@ -4,13 +4,16 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"btc-dex-dashboard/internal/api/handler"
 	"btc-dex-dashboard/internal/api/middleware"
 	"btc-dex-dashboard/internal/config"
+	"btc-dex-dashboard/internal/domain/model"
 	"btc-dex-dashboard/internal/infrastructure/database"
 	"btc-dex-dashboard/internal/infrastructure/dex"
+	"btc-dex-dashboard/internal/infrastructure/notifier"
 	"btc-dex-dashboard/internal/job"
 	"btc-dex-dashboard/internal/repository"
 	"btc-dex-dashboard/internal/service"
@@ -24,16 +27,102 @@ func main() {
 	if err != nil {
 		log.Fatal("failed to load config:", err)
 	}
-	log.Printf("Config loaded: port=%s, db=%s, interval=%ds",
-		cfg.Server.Port, cfg.Database.Path, cfg.Job.IntervalSeconds)
+	log.Printf("Config loaded: port=%s, db=%s, interval=%ds, funding_interval=%ds",
+		cfg.Server.Port, cfg.Database.Path, cfg.Job.IntervalSeconds, cfg.Job.FundingIntervalSeconds)
 
 	db, err := database.NewDB(cfg.Database.Path)
 	if err != nil {
 		log.Fatal("failed to connect database:", err)
 	}
 
-	if err := database.Seed(db); err != nil {
-		log.Fatal("failed to seed database:", err)
+	// DEX クライアント（429 / 5xx は再試行し、連続して失敗する取引所はしばらく呼ばない）
+	transportConfig := dex.TransportConfig{
+		Timeout:         time.Duration(cfg.Transport.TimeoutSeconds) * time.Second,
+		MaxRetries:      cfg.Transport.MaxRetries,
+		InitialBackoff:  time.Duration(cfg.Transport.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:      time.Duration(cfg.Transport.MaxBackoffMs) * time.Millisecond,
+		BreakerFailures: cfg.Transport.BreakerFailures,
+		BreakerCooldown: time.Duration(cfg.Transport.BreakerCooldownSeconds) * time.Second,
+	}
+	// 取引所ごとのレート制限。同じ取引所への全リクエストで共有する
+	limiters := make(map[string]*dex.RateLimiter)
+	for key, rl := range cfg.RateLimits {
+		if rl.RequestsPerSecond <= 0 {
+			continue
+		}
+		limiters[key] = dex.NewRateLimiter(key, dex.RateLimit{
+			RequestsPerSecond: rl.RequestsPerSecond,
+			Burst:             rl.Burst,
+			Weights:           rl.Weights,
+		})
+	}
+
+	// config.yaml の取引所定義からクライアントを生成し、取引所・マーケットを DB に反映
+	var clients []dex.DexClient
+	exchangeTypes := make(map[string]string) // 取引所キー → 種類
+	references := make(map[string]bool)      // 公正価格の参照にのみ使う取引所キー
+	var specs []database.ExchangeSpec
+	for _, ec := range cfg.Exchanges {
+		if ec.Key == "" {
+			log.Fatal("exchange key is required in config")
+		}
+		typ := ec.Type
+		if typ == "" {
+			typ = ec.Key
+		}
+		tc := transportConfig
+		if ec.TimeoutSeconds > 0 {
+			tc.Timeout = time.Duration(ec.TimeoutSeconds) * time.Second
+		}
+		client, err := dex.NewClient(typ,
+			dex.WithName(ec.Key),
+			dex.WithBaseURL(ec.BaseURL),
+			dex.WithStreamURL(ec.StreamURL),
+			dex.WithDataURL(ec.DataURL),
+			dex.WithTransportConfig(tc),
+			dex.WithRateLimiter(limiters[ec.Key]),
+		)
+		if err != nil {
+			log.Fatalf("invalid exchange %q in config: %v", ec.Key, err)
+		}
+		clients = append(clients, client)
+		exchangeTypes[ec.Key] = typ
+		references[ec.Key] = ec.Reference
+
+		marketConfigs := ec.Markets
+		if len(marketConfigs) == 0 {
+			for _, ac := range cfg.Assets {
+				marketConfigs = append(marketConfigs, config.MarketConfig{Asset: ac.Name})
+			}
+		}
+		spec := database.ExchangeSpec{Key: ec.Key, DisplayName: ec.DisplayName, Reference: ec.Reference}
+		if spec.DisplayName == "" {
+			spec.DisplayName = ec.Key
+		}
+		fees, ok := cfg.Fees[ec.Key]
+		if ec.Fees != nil {
+			fees = *ec.Fees
+		} else if ok {
+			log.Printf("[%s] top-level fees is deprecated, move it to exchanges[].fees", ec.Key)
+		}
+		spec.MakerFee, spec.TakerFee = fees.Maker, fees.Taker
+		quoteAsset := strings.ToUpper(ec.QuoteAsset)
+		if quoteAsset == "" {
+			quoteAsset = dex.QuoteAsset(typ)
+		}
+		for _, mc := range marketConfigs {
+			asset := strings.ToUpper(mc.Asset)
+			symbol := mc.Symbol
+			if symbol == "" {
+				symbol = client.Symbol(asset)
+			}
+			spec.Markets = append(spec.Markets, database.MarketSpec{Symbol: symbol, BaseAsset: asset, QuoteAsset: quoteAsset})
+		}
+		specs = append(specs, spec)
+	}
+
+	if err := database.Reconcile(db, specs); err != nil {
+		log.Fatal("failed to reconcile exchanges:", err)
 	}
 	log.Println("Database initialized successfully")
 
@@ -42,38 +131,210 @@ func main() {
 	marketRepo := repository.NewGormMarketRepository(db)
 	priceRepo := repository.NewGormPriceRepository(db)
 	fundingRepo := repository.NewGormFundingRateRepository(db)
+	statsRepo := repository.NewGormMarketStatsRepository(db)
+	candleRepo := repository.NewGormCandleRepository(db)
+	oppRepo := repository.NewGormOpportunityRepository(db)
+	alertRuleRepo := repository.NewGormAlertRuleRepository(db)
 
-	// MarketID を取得（DEX名 → MarketID のマッピング）
 	ctx := context.Background()
+
+	// config.yaml のアラートルールを DB に反映し、設定から削除されたものは無効にする
+	// （API で追加したルールは対象外）
+	ruleNames := make([]string, 0, len(cfg.Alerts.Rules))
+	for _, rc := range cfg.Alerts.Rules {
+		rule := &model.AlertRule{
+			Name:            rc.Name,
+			Type:            rc.Type,
+			Threshold:       rc.Threshold,
+			ForSeconds:      rc.ForSeconds,
+			CooldownSeconds: rc.CooldownSeconds,
+			Exchange:        rc.Exchange,
+			Asset:           strings.ToUpper(rc.Asset),
+			BuyExchange:     rc.BuyExchange,
+			SellExchange:    rc.SellExchange,
+			Disabled:        rc.Disabled,
+			FromConfig:      true,
+		}
+		if err := service.ValidateAlertRule(rule); err != nil {
+			log.Fatal("invalid alert rule in config:", err)
+		}
+		if err := alertRuleRepo.UpsertByName(ctx, rule); err != nil {
+			log.Fatal("failed to save alert rule:", err)
+		}
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	if n, err := alertRuleRepo.DisableConfigRulesExcept(ctx, ruleNames); err != nil {
+		log.Fatal("failed to disable removed alert rules:", err)
+	} else if n > 0 {
+		log.Printf("Disabled %d alert rule(s) removed from config", n)
+	}
+
+	// 有効なマーケットを取得対象にする（取引所キー → 取得対象）
 	markets, err := marketRepo.FindAll(ctx)
 	if err != nil {
 		log.Fatal("failed to get markets:", err)
 	}
-	marketIDs := make(map[string]uint)
+	var assets []string
+	seenAssets := make(map[string]bool)
+	targetsByExchange := make(map[string][]job.FetchTarget)
+	symbolsByExchange := make(map[string][]string)
 	for _, m := range markets {
-		marketIDs[m.Exchange.Key] = m.ID
+		key := m.Exchange.Key
+		targetsByExchange[key] = append(targetsByExchange[key], job.FetchTarget{MarketID: m.ID, Symbol: m.Symbol})
+		symbolsByExchange[key] = append(symbolsByExchange[key], m.Symbol)
+		if !seenAssets[m.BaseAsset] {
+			seenAssets[m.BaseAsset] = true
+			assets = append(assets, m.BaseAsset)
+		}
 	}
+	log.Printf("Tracking assets: %s", strings.Join(assets, ", "))
 
-	// DEX クライアント
-	clients := []dex.DexClient{
-		dex.NewHyperliquidClient(),
-		dex.NewLighterClient(),
-		dex.NewAsterClient(),
+	// 統計（マーク価格・建玉・出来高）は対応している取引所のみ取得する。
+	// StreamingClient は MarketStatsClient を実装しないため包む前に振り分ける
+	var statsTargets []job.FetchTarget
+	for _, c := range clients {
+		if _, ok := c.(dex.MarketStatsClient); !ok {
+			continue
+		}
+		for _, t := range targetsByExchange[c.Name()] {
+			t.Client = c
+			statsTargets = append(statsTargets, t)
+		}
+	}
+
+	// WebSocket で受信した最新の気配値を使う（切断中は REST にフォールバック）
+	if cfg.Streaming.Enabled {
+		staleAfter := time.Duration(cfg.Streaming.StaleAfterSeconds) * time.Second
+		for i, c := range clients {
+			stream, err := dex.NewStreamingClient(exchangeTypes[c.Name()], c, symbolsByExchange[c.Name()], staleAfter)
+			if err != nil {
+				log.Fatal("failed to create streaming client:", err)
+			}
+			if stream == nil {
+				continue
+			}
+			go stream.Start(ctx)
+			clients[i] = stream
+		}
+	}
+
+	var targets, fundingTargets []job.FetchTarget
+	for _, c := range clients {
+		for _, t := range targetsByExchange[c.Name()] {
+			t.Client = c
+			targets = append(targets, t)
+			// 参照取引所の Funding Rate は使わないため取得しない
+			if !references[c.Name()] {
+				fundingTargets = append(fundingTargets, t)
+			}
+		}
+	}
+
+	// Service
+	healthStaleAfter := time.Duration(cfg.Health.StaleAfterSeconds) * time.Second
+	healthService := service.NewHealthService(exchangeRepo, healthStaleAfter, cfg.Health.MaxConsecutiveFailures, limiters)
+	// 履歴は生の価格データから集計するため、期間の上限は保持期間になる
+	rawRetention := time.Duration(cfg.Retention.RawPricesHours) * time.Hour
+	if err := service.ValidateRawRetention(rawRetention); err != nil {
+		log.Fatal("invalid retention.raw_prices_hours:", err)
+	}
+	if rawRetention > 0 && time.Duration(cfg.Job.CandleBackfillHours)*time.Hour > rawRetention {
+		log.Fatalf("job.candle_backfill_hours (%d) must not exceed retention.raw_prices_hours (%d)", cfg.Job.CandleBackfillHours, cfg.Retention.RawPricesHours)
+	}
+	log.Printf("Spread history is limited to the last %v", service.MaxHistoryWindow(rawRetention))
+	spreadService := service.NewSpreadService(marketRepo, priceRepo, healthService, service.SpreadOptions{
+		AlignOnExchangeTime: cfg.Spread.AlignOnExchangeTime,
+		MaxSkew:             time.Duration(cfg.Spread.MaxSkewMs) * time.Millisecond,
+		StaleAfter:          healthStaleAfter,
+		RawRetention:        rawRetention,
+	})
+	fundingService := service.NewFundingService(marketRepo, fundingRepo)
+	marketStatsService := service.NewMarketStatsService(marketRepo, statsRepo, priceRepo)
+	// Funding Rate の保存時刻は分単位に丸めるため、取得2回分に1分の余裕を足した時間を古いとみなす
+	fundingInterval := time.Duration(cfg.Job.FundingIntervalSeconds) * time.Second
+	fundingArbitrageService := service.NewFundingArbitrageService(fundingService, spreadService, 2*fundingInterval+time.Minute)
+	depthService := service.NewDepthService(clients, marketRepo)
+	spreadHub := service.NewSpreadHub(spreadService)
+	candleService := service.NewCandleService(marketRepo, candleRepo)
+	opportunityService := service.NewOpportunityService(spreadService, oppRepo, assets, cfg.Opportunity.ThresholdPct)
+	if err := opportunityService.LoadOpen(ctx); err != nil {
+		log.Fatal("failed to load open opportunities:", err)
+	}
+
+	// 通知先（失敗時は指数バックオフで再送）
+	var notifiers []notifier.Notifier
+	if cfg.Alerts.WebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(cfg.Alerts.WebhookURL))
+	}
+	if cfg.Alerts.Slack.WebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewSlackNotifier(cfg.Alerts.Slack.WebhookURL, cfg.Alerts.DashboardURL))
+	}
+	if cfg.Alerts.Discord.WebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewDiscordNotifier(cfg.Alerts.Discord.WebhookURL, cfg.Alerts.DashboardURL))
+	}
+	if cfg.Alerts.Telegram.BotToken != "" {
+		notifiers = append(notifiers, notifier.NewTelegramNotifier(
+			cfg.Alerts.Telegram.BaseURL, cfg.Alerts.Telegram.BotToken, cfg.Alerts.Telegram.ChatID, cfg.Alerts.DashboardURL))
+	}
+	retryBackoff := time.Duration(cfg.Alerts.Retry.InitialBackoffMs) * time.Millisecond
+	for i, n := range notifiers {
+		notifiers[i] = notifier.NewRetryNotifier(n, cfg.Alerts.Retry.MaxAttempts, retryBackoff)
+	}
+	alertService := service.NewAlertService(marketRepo, priceRepo, fundingRepo, alertRuleRepo, spreadService, notifiers)
+	if err := alertService.LoadRules(ctx); err != nil {
+		log.Fatal("failed to load alert rules:", err)
 	}
 
 	// 定期ジョブ
 	interval := time.Duration(cfg.Job.IntervalSeconds) * time.Second
-	fetcher := job.NewPriceFetcher(clients, priceRepo, marketIDs)
-	scheduler := job.NewScheduler(fetcher, interval)
+	fetchTimeout := time.Duration(cfg.Job.FetchTimeoutMs) * time.Millisecond
+	fetcher := job.NewPriceFetcher(targets, priceRepo, healthService, fetchTimeout)
+	fetcher.AddListener(spreadHub)
+	fetcher.AddListener(opportunityService)
+	fetcher.AddListener(alertService)
+	go fetcher.RunListeners(ctx)
+	scheduler := job.NewScheduler("prices", fetcher, interval)
 	go scheduler.Start(ctx)
 
-	// Service
-	spreadService := service.NewSpreadService(marketRepo, priceRepo)
-	fundingService := service.NewFundingService(marketRepo, fundingRepo)
+	fundingFetcher := job.NewFundingFetcher(fundingTargets, fundingRepo, fetchTimeout)
+	fundingScheduler := job.NewScheduler("funding rates", fundingFetcher, fundingInterval)
+	go fundingScheduler.Start(ctx)
+
+	statsInterval := time.Duration(cfg.Job.StatsIntervalSeconds) * time.Second
+	statsFetcher := job.NewMarketStatsFetcher(statsTargets, statsRepo, fetchTimeout)
+	statsScheduler := job.NewScheduler("market stats", statsFetcher, statsInterval)
+	go statsScheduler.Start(ctx)
+
+	candleInterval := time.Duration(cfg.Job.CandleIntervalSeconds) * time.Second
+	candleBackfill := time.Duration(cfg.Job.CandleBackfillHours) * time.Hour
+	candleAggregator := job.NewCandleAggregator(marketRepo, priceRepo, candleRepo, candleBackfill)
+	candleScheduler := job.NewScheduler("candles", candleAggregator, candleInterval)
+	go candleScheduler.Start(ctx)
+
+	retentionPolicy := job.RetentionPolicy{
+		RawPrices: rawRetention,
+		Candles:   make(map[string]time.Duration),
+		BatchSize: cfg.Retention.BatchSize,
+	}
+	for interval, days := range cfg.Retention.CandleDays {
+		retentionPolicy.Candles[interval] = time.Duration(days) * 24 * time.Hour
+	}
+	retentionJob := job.NewRetentionJob(priceRepo, candleRepo, retentionPolicy)
+	retentionInterval := time.Duration(cfg.Retention.IntervalMinutes) * time.Minute
+	retentionScheduler := job.NewScheduler("retention", job.FetcherFunc(retentionJob.Run), retentionInterval)
+	go retentionScheduler.Start(ctx)
 
 	// Handler
 	spreadHandler := handler.NewSpreadHandler(spreadService)
 	fundingHandler := handler.NewFundingHandler(fundingService)
+	marketStatsHandler := handler.NewMarketStatsHandler(marketStatsService)
+	fundingArbitrageHandler := handler.NewFundingArbitrageHandler(fundingArbitrageService)
+	depthHandler := handler.NewDepthHandler(depthService)
+	streamHandler := handler.NewStreamHandler(spreadHub)
+	candleHandler := handler.NewCandleHandler(candleService)
+	opportunityHandler := handler.NewOpportunityHandler(opportunityService)
+	alertHandler := handler.NewAlertHandler(alertService)
+	exchangeHandler := handler.NewExchangeHandler(healthService)
 
 	r := gin.Default()
 
@@ -96,8 +357,18 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"exchanges": exchanges})
 	})
 
+	r.GET("/api/exchanges/status", exchangeHandler.GetStatus)
 	r.GET("/api/spread", spreadHandler.GetSpread)
 	r.GET("/api/funding-rates", fundingHandler.GetRates)
+	r.GET("/api/funding/arbitrage", fundingArbitrageHandler.GetArbitrage)
+	r.GET("/api/markets/stats", marketStatsHandler.GetStats)
+	r.GET("/api/arbitrage/depth", depthHandler.GetDepthArbitrage)
+	r.GET("/api/stream", streamHandler.Stream)
+	r.GET("/api/candles", candleHandler.GetCandles)
+	r.GET("/api/opportunities", opportunityHandler.GetOpportunities)
+	r.GET("/api/alerts/rules", alertHandler.GetRules)
+	r.POST("/api/alerts/rules", alertHandler.CreateRule)
+	r.DELETE("/api/alerts/rules/:id", alertHandler.DeleteRule)
 
 	log.Printf("Server starting on :%s", cfg.Server.Port)
 	r.Run(":" + cfg.Server.Port)
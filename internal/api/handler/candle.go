@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandleHandler struct {
+	candleService *service.CandleService
+}
+
+func NewCandleHandler(candleService *service.CandleService) *CandleHandler {
+	return &CandleHandler{candleService: candleService}
+}
+
+// GetCandles は exchange, asset（省略時は BTC）, interval（1m / 5m / 1h）, from, to（RFC3339）を受け付ける。
+// from / to を省略した場合は直近24時間
+func (h *CandleHandler) GetCandles(c *gin.Context) {
+	exchange := c.Query("exchange")
+	if exchange == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exchange is required"})
+		return
+	}
+	interval := c.DefaultQuery("interval", "1m")
+
+	to := time.Now()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to: " + err.Error()})
+			return
+		}
+		to = t
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from: " + err.Error()})
+			return
+		}
+		from = t
+	}
+
+	result, err := h.candleService.GetCandles(c.Request.Context(), exchange, queryAsset(c), interval, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCandleQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOrderBookDepth = 20
+	maxOrderBookDepth     = 100
+)
+
+type DepthHandler struct {
+	depthService *service.DepthService
+}
+
+func NewDepthHandler(depthService *service.DepthService) *DepthHandler {
+	return &DepthHandler{depthService: depthService}
+}
+
+func (h *DepthHandler) GetDepthArbitrage(c *gin.Context) {
+	depth := defaultOrderBookDepth
+	if v := c.Query("depth"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 1 || d > maxOrderBookDepth {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "depth must be an integer between 1 and 100"})
+			return
+		}
+		depth = d
+	}
+
+	result, err := h.depthService.CalculateDepthArbitrage(c.Request.Context(), queryAsset(c), depth)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
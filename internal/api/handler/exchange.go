@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeHandler struct {
+	healthService *service.HealthService
+}
+
+func NewExchangeHandler(healthService *service.HealthService) *ExchangeHandler {
+	return &ExchangeHandler{healthService: healthService}
+}
+
+// GetStatus は取引所ごとの価格取得の状態を返す
+func (h *ExchangeHandler) GetStatus(c *gin.Context) {
+	statuses, err := h.healthService.Status(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"exchanges": statuses})
+}
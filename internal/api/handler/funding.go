@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"btc-dex-dashboard/internal/service"
@@ -17,8 +18,12 @@ func NewFundingHandler(fundingService *service.FundingService) *FundingHandler {
 }
 
 func (h *FundingHandler) GetRates(c *gin.Context) {
-	result, err := h.fundingService.GetLatestRates(c.Request.Context())
+	result, err := h.fundingService.GetLatestRates(c.Request.Context(), queryAsset(c))
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
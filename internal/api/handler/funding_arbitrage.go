@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultHoldingHours = 24
+	maxHoldingHours     = 24 * 365
+)
+
+type FundingArbitrageHandler struct {
+	arbitrageService *service.FundingArbitrageService
+}
+
+func NewFundingArbitrageHandler(arbitrageService *service.FundingArbitrageService) *FundingArbitrageHandler {
+	return &FundingArbitrageHandler{arbitrageService: arbitrageService}
+}
+
+func (h *FundingArbitrageHandler) GetArbitrage(c *gin.Context) {
+	hours := float64(defaultHoldingHours)
+	if v := c.Query("hours"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 || parsed > maxHoldingHours {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "hours must be a number greater than 0 and at most 8760"})
+			return
+		}
+		hours = parsed
+	}
+
+	result, err := h.arbitrageService.Calculate(c.Request.Context(), queryAsset(c), hours)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
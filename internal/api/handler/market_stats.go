@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarketStatsHandler struct {
+	statsService *service.MarketStatsService
+}
+
+func NewMarketStatsHandler(statsService *service.MarketStatsService) *MarketStatsHandler {
+	return &MarketStatsHandler{statsService: statsService}
+}
+
+func (h *MarketStatsHandler) GetStats(c *gin.Context) {
+	result, err := h.statsService.GetLatestStats(c.Request.Context(), queryAsset(c))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"btc-dex-dashboard/internal/repository"
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOpportunityLimit = 100
+	maxOpportunityLimit     = 1000
+)
+
+type OpportunityHandler struct {
+	opportunityService *service.OpportunityService
+}
+
+func NewOpportunityHandler(opportunityService *service.OpportunityService) *OpportunityHandler {
+	return &OpportunityHandler{opportunityService: opportunityService}
+}
+
+// GetOpportunities は asset（銘柄）, buy, sell（取引所キー）, min_duration（例: 10s）,
+// from, to（RFC3339）, limit でアービトラージ機会を絞り込む
+func (h *OpportunityHandler) GetOpportunities(c *gin.Context) {
+	filter := repository.OpportunityFilter{
+		Asset:        strings.ToUpper(c.Query("asset")),
+		BuyExchange:  c.Query("buy"),
+		SellExchange: c.Query("sell"),
+		Limit:        defaultOpportunityLimit,
+	}
+
+	if v := c.Query("min_duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_duration"})
+			return
+		}
+		filter.MinDuration = d
+	}
+
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from: " + err.Error()})
+			return
+		}
+		filter.From = t
+	}
+
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to: " + err.Error()})
+			return
+		}
+		filter.To = t
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxOpportunityLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer between 1 and 1000"})
+			return
+		}
+		filter.Limit = n
+	}
+
+	result, err := h.opportunityService.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"btc-dex-dashboard/internal/service"
 
@@ -16,11 +20,91 @@ func NewSpreadHandler(spreadService *service.SpreadService) *SpreadHandler {
 	return &SpreadHandler{spreadService: spreadService}
 }
 
+// GetSpread は履歴の期間を以下のクエリパラメータで受け付ける
+//   - asset: 銘柄（例: BTC, ETH）。省略時は BTC
+//   - window: 現在からの期間（例: 1h, 24h）
+//   - from, to: RFC3339 形式の期間（window と同時指定不可）
+//   - bucket: 集計バケット幅（例: 10s, 1m）。省略時は期間から自動で決める
 func (h *SpreadHandler) GetSpread(c *gin.Context) {
-	result, err := h.spreadService.CalculateSpread(c.Request.Context())
+	query, err := parseHistoryQuery(c, time.Now())
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.spreadService.CalculateSpread(c.Request.Context(), queryAsset(c), query)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidHistoryQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, result)
-}
\ No newline at end of file
+}
+
+// queryAsset は asset クエリパラメータを大文字で返す。省略時は BTC
+func queryAsset(c *gin.Context) string {
+	return strings.ToUpper(c.DefaultQuery("asset", service.DefaultAsset))
+}
+
+func parseHistoryQuery(c *gin.Context, now time.Time) (service.HistoryQuery, error) {
+	windowStr := c.Query("window")
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+	bucketStr := c.Query("bucket")
+
+	if windowStr == "" && fromStr == "" && toStr == "" && bucketStr == "" {
+		return service.DefaultHistoryQuery(now), nil
+	}
+
+	var bucket time.Duration
+	if bucketStr != "" {
+		d, err := time.ParseDuration(bucketStr)
+		if err != nil {
+			return service.HistoryQuery{}, fmt.Errorf("invalid bucket: %w", err)
+		}
+		// 自動で決めるのは省略時のみ。0 や負の値は誤りとして返す
+		if d <= 0 {
+			return service.HistoryQuery{}, fmt.Errorf("invalid bucket: must be positive")
+		}
+		bucket = d
+	}
+
+	if windowStr != "" && (fromStr != "" || toStr != "") {
+		return service.HistoryQuery{}, fmt.Errorf("window cannot be combined with from/to")
+	}
+
+	if windowStr != "" {
+		window, err := time.ParseDuration(windowStr)
+		if err != nil {
+			return service.HistoryQuery{}, fmt.Errorf("invalid window: %w", err)
+		}
+		return service.NewHistoryQuery(now.Add(-window), now, bucket), nil
+	}
+
+	to := now
+	if toStr != "" {
+		t, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return service.HistoryQuery{}, fmt.Errorf("invalid to: %w", err)
+		}
+		to = t
+	}
+
+	from := service.DefaultHistoryQuery(to).From
+	if fromStr != "" {
+		t, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return service.HistoryQuery{}, fmt.Errorf("invalid from: %w", err)
+		}
+		from = t
+	}
+
+	return service.NewHistoryQuery(from, to, bucket), nil
+}
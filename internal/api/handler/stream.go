@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"btc-dex-dashboard/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeatInterval は更新がない間に送るコメント行の間隔。
+// 価格取得が止まっていてもプロキシやロードバランサーにアイドル接続として切られないようにする
+const streamHeartbeatInterval = 15 * time.Second
+
+type StreamHandler struct {
+	hub *service.SpreadHub
+}
+
+func NewStreamHandler(hub *service.SpreadHub) *StreamHandler {
+	return &StreamHandler{hub: hub}
+}
+
+// Stream は Server-Sent Events で asset（省略時は BTC）のスプレッドのスナップショットを配信する
+func (h *StreamHandler) Stream(c *gin.Context) {
+	updates, unsubscribe, err := h.hub.Subscribe(c.Request.Context(), queryAsset(c))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAsset) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case result := <-updates:
+			c.SSEvent("spread", result)
+			return true
+		case <-heartbeat.C:
+			// ":" で始まる行は SSE のコメントで、EventSource はイベントとして扱わない
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
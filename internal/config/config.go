@@ -1,14 +1,27 @@
 package config
 
 import (
+	"os"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	CORS     CORSConfig     `mapstructure:"cors"`
-	Job      JobConfig      `mapstructure:"job"`
+	Server      ServerConfig               `mapstructure:"server"`
+	Database    DatabaseConfig             `mapstructure:"database"`
+	CORS        CORSConfig                 `mapstructure:"cors"`
+	Job         JobConfig                  `mapstructure:"job"`
+	Fees        map[string]FeeConfig       `mapstructure:"fees"` // 旧形式（取引所キー → 手数料率）。exchanges[].fees がない取引所にのみ使う
+	Retention   RetentionConfig            `mapstructure:"retention"`
+	Opportunity OpportunityConfig          `mapstructure:"opportunity"`
+	Alerts      AlertsConfig               `mapstructure:"alerts"`
+	Streaming   StreamingConfig            `mapstructure:"streaming"`
+	Spread      SpreadConfig               `mapstructure:"spread"`
+	Health      HealthConfig               `mapstructure:"health"`
+	Transport   TransportConfig            `mapstructure:"transport"`
+	RateLimits  map[string]RateLimitConfig `mapstructure:"rate_limits"`
+	Assets      []AssetConfig              `mapstructure:"assets"`
+	Exchanges   []ExchangeConfig           `mapstructure:"exchanges"`
 }
 
 type ServerConfig struct {
@@ -24,19 +37,195 @@ type CORSConfig struct {
 }
 
 type JobConfig struct {
-	IntervalSeconds int `mapstructure:"interval_seconds"`
+	IntervalSeconds        int `mapstructure:"interval_seconds"`
+	FundingIntervalSeconds int `mapstructure:"funding_interval_seconds"`
+	StatsIntervalSeconds   int `mapstructure:"stats_interval_seconds"` // マーク価格・建玉・出来高の取得間隔
+	CandleIntervalSeconds  int `mapstructure:"candle_interval_seconds"`
+	CandleBackfillHours    int `mapstructure:"candle_backfill_hours"`
+	FetchTimeoutMs         int `mapstructure:"fetch_timeout_ms"` // 1取引所あたりの取得の期限（再試行を含む）
+}
+
+// AssetConfig は追跡する銘柄。markets を指定しない取引所はこの全銘柄を既定のシンボルで扱う
+type AssetConfig struct {
+	Name string `mapstructure:"name"`
+}
+
+// ExchangeConfig は取引所の定義。起動時に key をキーに DB へ反映し、定義から消えた取引所は無効にする
+type ExchangeConfig struct {
+	Key            string         `mapstructure:"key"`
+	Type           string         `mapstructure:"type"` // クライアントの種類（hyperliquid / lighter / aster / dydx / paradex / vertex / drift / binance / bybit / okx）。省略時は key
+	DisplayName    string         `mapstructure:"display_name"`
+	BaseURL        string         `mapstructure:"base_url"`        // 省略時は本番の API
+	StreamURL      string         `mapstructure:"stream_url"`      // WebSocket の接続先。省略時は本番
+	DataURL        string         `mapstructure:"data_url"`        // 板と別ホストの REST API（Vertex の archive、Drift の data API）。省略時は本番
+	TimeoutSeconds int            `mapstructure:"timeout_seconds"` // 0 なら transport.timeout_seconds
+	Reference      bool           `mapstructure:"reference"`       // 公正価格の参照にのみ使い、アービトラージの対象にしない（CEX）
+	QuoteAsset     string         `mapstructure:"quote_asset"`     // 価格の単位（USD / USDT など）。省略時は種類ごとの既定
+	Fees           *FeeConfig     `mapstructure:"fees"`            // 手数料率。省略時は最上位の fees、それもなければ0
+	Markets        []MarketConfig `mapstructure:"markets"`         // 省略時は assets の全銘柄
+}
+
+// MarketConfig は取引所で扱う銘柄。symbol を省略した場合は取引所の既定の形式（BTC / BTC-PERP / BTCUSDT）
+type MarketConfig struct {
+	Asset  string `mapstructure:"asset"`
+	Symbol string `mapstructure:"symbol"`
+}
+
+// RetentionConfig はデータ保持期間の設定。0 は無期限
+type RetentionConfig struct {
+	IntervalMinutes int            `mapstructure:"interval_minutes"`
+	BatchSize       int            `mapstructure:"batch_size"`
+	RawPricesHours  int            `mapstructure:"raw_prices_hours"`
+	CandleDays      map[string]int `mapstructure:"candle_days"` // 足の種類 → 保持日数
+}
+
+// StreamingConfig は WebSocket による価格受信の設定
+type StreamingConfig struct {
+	Enabled           bool `mapstructure:"enabled"`
+	StaleAfterSeconds int  `mapstructure:"stale_after_seconds"` // これより古い気配値は REST で取り直す
+}
+
+// SpreadConfig はスプレッド計算での時刻の揃え方
+type SpreadConfig struct {
+	AlignOnExchangeTime bool `mapstructure:"align_on_exchange_time"` // 取引所の付与した時刻で比較する
+	MaxSkewMs           int  `mapstructure:"max_skew_ms"`            // 最新の気配値からこれ以上古いものは除外。0 なら除外しない
+}
+
+// TransportConfig は DEX への HTTP リクエストの再試行とサーキットブレーカーの設定
+type TransportConfig struct {
+	TimeoutSeconds         int `mapstructure:"timeout_seconds"`
+	MaxRetries             int `mapstructure:"max_retries"`
+	InitialBackoffMs       int `mapstructure:"initial_backoff_ms"`
+	MaxBackoffMs           int `mapstructure:"max_backoff_ms"`
+	BreakerFailures        int `mapstructure:"breaker_failures"`         // 0 ならブレーカーなし
+	BreakerCooldownSeconds int `mapstructure:"breaker_cooldown_seconds"` // 開いてから再試行を許可するまで
+}
+
+// RateLimitConfig は取引所ごとのトークンバケットの設定。requests_per_second が 0 なら制限なし
+type RateLimitConfig struct {
+	RequestsPerSecond float64        `mapstructure:"requests_per_second"` // 1秒あたりに回復する重み
+	Burst             int            `mapstructure:"burst"`
+	Weights           map[string]int `mapstructure:"weights"` // エンドポイント → 重み（未指定は 1）
+}
+
+// HealthConfig は取引所の死活判定の設定
+type HealthConfig struct {
+	StaleAfterSeconds      int `mapstructure:"stale_after_seconds"`      // これ以上取得に成功していないマーケットの気配値は使わない
+	MaxConsecutiveFailures int `mapstructure:"max_consecutive_failures"` // この回数続けて取得に失敗したマーケットの気配値は使わない（0 で無効）
+}
+
+// OpportunityConfig はアービトラージ機会の記録条件
+type OpportunityConfig struct {
+	ThresholdPct float64 `mapstructure:"threshold_pct"` // 手数料控除後のスプレッド（%）
+}
+
+// AlertsConfig はアラートの通知先とルール
+type AlertsConfig struct {
+	WebhookURL   string            `mapstructure:"webhook_url"`   // 空なら Webhook 通知なし
+	DashboardURL string            `mapstructure:"dashboard_url"` // 通知に載せるダッシュボードへのリンク
+	Retry        RetryConfig       `mapstructure:"retry"`
+	Slack        SlackConfig       `mapstructure:"slack"`
+	Discord      DiscordConfig     `mapstructure:"discord"`
+	Telegram     TelegramConfig    `mapstructure:"telegram"`
+	Rules        []AlertRuleConfig `mapstructure:"rules"`
+}
+
+// RetryConfig は通知失敗時の再送設定
+type RetryConfig struct {
+	MaxAttempts      int `mapstructure:"max_attempts"`
+	InitialBackoffMs int `mapstructure:"initial_backoff_ms"`
+}
+
+// SlackConfig は Slack Incoming Webhook の設定。空なら通知なし
+type SlackConfig struct {
+	WebhookURL string `mapstructure:"webhook_url"`
 }
 
+// DiscordConfig は Discord Webhook の設定。空なら通知なし
+type DiscordConfig struct {
+	WebhookURL string `mapstructure:"webhook_url"`
+}
+
+// TelegramConfig は Telegram Bot API の設定。bot_token が空なら通知なし
+type TelegramConfig struct {
+	BaseURL  string `mapstructure:"base_url"`
+	BotToken string `mapstructure:"bot_token"`
+	ChatID   string `mapstructure:"chat_id"`
+}
+
+// AlertRuleConfig は config.yaml で定義するアラートルール。起動時に name をキーに DB へ反映する
+type AlertRuleConfig struct {
+	Name            string  `mapstructure:"name"`
+	Type            string  `mapstructure:"type"`
+	Threshold       float64 `mapstructure:"threshold"`
+	ForSeconds      int     `mapstructure:"for_seconds"`
+	CooldownSeconds int     `mapstructure:"cooldown_seconds"`
+	Exchange        string  `mapstructure:"exchange"`
+	Asset           string  `mapstructure:"asset"` // 空なら BTC
+	BuyExchange     string  `mapstructure:"buy_exchange"`
+	SellExchange    string  `mapstructure:"sell_exchange"`
+	Disabled        bool    `mapstructure:"disabled"`
+}
+
+// FeeConfig は取引所ごとの手数料率（0.00045 = 0.045%）
+type FeeConfig struct {
+	Maker float64 `mapstructure:"maker"`
+	Taker float64 `mapstructure:"taker"`
+}
+
+// Load は config.yaml を読み込む。環境変数 CONFIG_FILE で別のファイル（config.mock.yaml など）を指定できる
 func Load() (*Config, error) {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
+	}
 
 	// デフォルト値
 	viper.SetDefault("server.port", "8080")
 	viper.SetDefault("database.path", "dev.db")
 	viper.SetDefault("cors.allowed_origins", []string{"http://localhost:5173"})
+	viper.SetDefault("assets", []map[string]interface{}{{"name": "BTC"}})
+	viper.SetDefault("exchanges", []map[string]interface{}{
+		{"key": "hyperliquid", "display_name": "Hyperliquid"},
+		{"key": "lighter", "display_name": "Lighter"},
+		{"key": "aster", "display_name": "Aster"},
+		{"key": "dydx", "display_name": "dYdX"},
+		{"key": "paradex", "display_name": "Paradex"},
+		{"key": "vertex", "display_name": "Vertex"},
+		{"key": "drift", "display_name": "Drift"},
+		{"key": "binance", "display_name": "Binance", "reference": true},
+		{"key": "bybit", "display_name": "Bybit", "reference": true},
+		{"key": "okx", "display_name": "OKX", "reference": true},
+	})
 	viper.SetDefault("job.interval_seconds", 2)
+	viper.SetDefault("job.funding_interval_seconds", 60)
+	viper.SetDefault("job.stats_interval_seconds", 60)
+	viper.SetDefault("job.candle_interval_seconds", 60)
+	viper.SetDefault("job.candle_backfill_hours", 48)
+	viper.SetDefault("job.fetch_timeout_ms", 1800)
+	viper.SetDefault("transport.timeout_seconds", 10)
+	viper.SetDefault("transport.max_retries", 2)
+	viper.SetDefault("transport.initial_backoff_ms", 200)
+	viper.SetDefault("transport.max_backoff_ms", 2000)
+	viper.SetDefault("transport.breaker_failures", 5)
+	viper.SetDefault("transport.breaker_cooldown_seconds", 30)
+	viper.SetDefault("retention.interval_minutes", 10)
+	viper.SetDefault("retention.batch_size", 5000)
+	viper.SetDefault("retention.raw_prices_hours", 48)
+	viper.SetDefault("opportunity.threshold_pct", 0.0)
+	viper.SetDefault("streaming.enabled", false)
+	viper.SetDefault("streaming.stale_after_seconds", 10)
+	viper.SetDefault("spread.align_on_exchange_time", false)
+	viper.SetDefault("spread.max_skew_ms", 0)
+	viper.SetDefault("health.stale_after_seconds", 30)
+	viper.SetDefault("health.max_consecutive_failures", 3)
+	viper.SetDefault("alerts.dashboard_url", "http://localhost:5173")
+	viper.SetDefault("alerts.retry.max_attempts", 5)
+	viper.SetDefault("alerts.retry.initial_backoff_ms", 500)
+	viper.SetDefault("alerts.telegram.base_url", "https://api.telegram.org")
 
 	// 環境変数での上書きを許可
 	viper.AutomaticEnv()
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// AlertRule はアラートルール。Threshold の単位は種類によって異なる
+//   - net_spread: 手数料控除後のスプレッド（%）
+//   - stale: 最終更新からの経過秒数
+//   - funding_diff: 取引所間の Funding Rate の差（%/h）
+type AlertRule struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Name            string    `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Type            string    `gorm:"size:30;not null" json:"type"`
+	Threshold       float64   `gorm:"not null" json:"threshold"`
+	ForSeconds      int       `gorm:"not null;default:0" json:"for_seconds"`
+	CooldownSeconds int       `gorm:"not null;default:0" json:"cooldown_seconds"`
+	Asset           string    `gorm:"size:20" json:"asset"`         // 対象の銘柄（空なら BTC）
+	Exchange        string    `gorm:"size:50" json:"exchange"`      // stale の対象（空なら全取引所）
+	BuyExchange     string    `gorm:"size:50" json:"buy_exchange"`  // net_spread の対象（空なら全て）
+	SellExchange    string    `gorm:"size:50" json:"sell_exchange"` // net_spread の対象（空なら全て）
+	Disabled        bool      `gorm:"not null;default:false" json:"disabled"`
+	FromConfig      bool      `gorm:"not null;default:false" json:"from_config"` // config.yaml のルール（設定から削除すると起動時に無効になる）
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Candle は価格の OHLC 足（bid / ask / 仲値）
+type Candle struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	MarketID  uint      `gorm:"not null;uniqueIndex:idx_candle_market_interval_ts" json:"market_id"`
+	Market    Market    `gorm:"foreignKey:MarketID" json:"market,omitempty"`
+	Interval  string    `gorm:"size:10;not null;uniqueIndex:idx_candle_market_interval_ts" json:"interval"`
+	Ts        time.Time `gorm:"not null;uniqueIndex:idx_candle_market_interval_ts" json:"ts"`
+	BidOpen   float64   `gorm:"type:decimal(20,8);not null" json:"bid_open"`
+	BidHigh   float64   `gorm:"type:decimal(20,8);not null" json:"bid_high"`
+	BidLow    float64   `gorm:"type:decimal(20,8);not null" json:"bid_low"`
+	BidClose  float64   `gorm:"type:decimal(20,8);not null" json:"bid_close"`
+	AskOpen   float64   `gorm:"type:decimal(20,8);not null" json:"ask_open"`
+	AskHigh   float64   `gorm:"type:decimal(20,8);not null" json:"ask_high"`
+	AskLow    float64   `gorm:"type:decimal(20,8);not null" json:"ask_low"`
+	AskClose  float64   `gorm:"type:decimal(20,8);not null" json:"ask_close"`
+	MidOpen   float64   `gorm:"type:decimal(20,8);not null" json:"mid_open"`
+	MidHigh   float64   `gorm:"type:decimal(20,8);not null" json:"mid_high"`
+	MidLow    float64   `gorm:"type:decimal(20,8);not null" json:"mid_low"`
+	MidClose  float64   `gorm:"type:decimal(20,8);not null" json:"mid_close"`
+	Count     int       `gorm:"not null" json:"count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CandleInterval は足の種類
+type CandleInterval struct {
+	Name     string
+	Duration time.Duration
+}
+
+// CandleIntervals は集計する足の一覧（短い順。長い足は直前の足から集計する）
+var CandleIntervals = []CandleInterval{
+	{Name: "1m", Duration: time.Minute},
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+}
+
+// FindCandleInterval は名前から足の種類を探す
+func FindCandleInterval(name string) (CandleInterval, bool) {
+	for _, ci := range CandleIntervals {
+		if ci.Name == name {
+			return ci, true
+		}
+	}
+	return CandleInterval{}, false
+}
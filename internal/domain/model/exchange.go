@@ -2,11 +2,16 @@ package model
 
 import "time"
 
-// Exchange は取引所マスタ
+// Exchange は取引所マスタ。config.yaml から削除された取引所は Disabled になる。
+// Reference は公正価格の算出にのみ使う参照取引所（CEX）で、アービトラージの対象にしない
 type Exchange struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	Key         string    `gorm:"uniqueIndex;size:50;not null" json:"key"`
 	DisplayName string    `gorm:"size:100;not null" json:"display_name"`
+	MakerFee    float64   `gorm:"type:decimal(10,6);not null;default:0" json:"maker_fee"`
+	TakerFee    float64   `gorm:"type:decimal(10,6);not null;default:0" json:"taker_fee"`
+	Reference   bool      `gorm:"not null;default:false" json:"reference"`
+	Disabled    bool      `gorm:"not null;default:false" json:"disabled"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
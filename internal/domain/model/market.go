@@ -2,7 +2,7 @@ package model
 
 import "time"
 
-// Market はマーケット（取引ペア）
+// Market はマーケット（取引ペア）。config.yaml から削除されたマーケットは Disabled になる
 type Market struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
 	ExchangeID uint      `gorm:"not null;index" json:"exchange_id"`
@@ -10,6 +10,7 @@ type Market struct {
 	Symbol     string    `gorm:"size:50;not null" json:"symbol"`
 	BaseAsset  string    `gorm:"size:20;not null" json:"base_asset"`
 	QuoteAsset string    `gorm:"size:20;not null" json:"quote_asset"`
+	Disabled   bool      `gorm:"not null;default:false" json:"disabled"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// MarketStats はマーケットの統計のスナップショット。取引所が返さない項目は nil
+type MarketStats struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	MarketID     uint      `gorm:"not null;uniqueIndex:idx_market_stats_market_ts" json:"market_id"`
+	Market       Market    `gorm:"foreignKey:MarketID" json:"market,omitempty"`
+	Ts           time.Time `gorm:"not null;uniqueIndex:idx_market_stats_market_ts" json:"ts"`
+	MarkPrice    *float64  `gorm:"type:decimal(20,8)" json:"mark_price"`
+	IndexPrice   *float64  `gorm:"type:decimal(20,8)" json:"index_price"`                  // インデックス価格（オラクル価格）
+	OpenInterest *float64  `gorm:"type:decimal(30,8)" json:"open_interest"`                // 建玉（基軸通貨建て）
+	Volume24h    *float64  `gorm:"column:volume_24h;type:decimal(30,8)" json:"volume_24h"` // 直近24時間の売買代金（クォート通貨建て）
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Opportunity はアービトラージ機会の発生から消滅までの記録。
+// EndedAt が nil の場合は継続中
+type Opportunity struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Asset           string     `gorm:"size:20;not null;default:BTC;index:idx_opportunity_pair" json:"asset"`
+	BuyExchange     string     `gorm:"size:50;not null;index:idx_opportunity_pair" json:"buy_exchange"`
+	SellExchange    string     `gorm:"size:50;not null;index:idx_opportunity_pair" json:"sell_exchange"`
+	StartedAt       time.Time  `gorm:"not null;index" json:"started_at"`
+	EndedAt         *time.Time `gorm:"index" json:"ended_at"`
+	DurationSeconds float64    `gorm:"not null;default:0" json:"duration_seconds"`
+	PeakNetSpread   float64    `gorm:"type:decimal(20,8);not null" json:"peak_net_spread"`
+	PeakNetPct      float64    `gorm:"type:decimal(20,10);not null" json:"peak_net_pct"`
+	PeakAt          time.Time  `gorm:"not null" json:"peak_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
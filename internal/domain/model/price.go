@@ -4,11 +4,13 @@ import "time"
 
 // Price は価格データ（リアルタイム / 秒単位）
 type Price struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	MarketID  uint      `gorm:"not null;uniqueIndex:idx_price_market_ts" json:"market_id"`
-	Market    Market    `gorm:"foreignKey:MarketID" json:"market,omitempty"`
-	Ts        time.Time `gorm:"not null;uniqueIndex:idx_price_market_ts" json:"ts"`
-	Bid       float64   `gorm:"type:decimal(20,8);not null" json:"bid"`
-	Ask       float64   `gorm:"type:decimal(20,8);not null" json:"ask"`
-	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	MarketID   uint       `gorm:"not null;uniqueIndex:idx_price_market_ts" json:"market_id"`
+	Market     Market     `gorm:"foreignKey:MarketID" json:"market,omitempty"`
+	Ts         time.Time  `gorm:"not null;uniqueIndex:idx_price_market_ts" json:"ts"` // ローカルで受信した時刻
+	Bid        float64    `gorm:"type:decimal(20,8);not null" json:"bid"`
+	Ask        float64    `gorm:"type:decimal(20,8);not null" json:"ask"`
+	ExchangeTs *time.Time `json:"exchange_ts"` // 取引所が付与した時刻（返さない取引所は nil）
+	LatencyMs  float64    `gorm:"not null;default:0" json:"latency_ms"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
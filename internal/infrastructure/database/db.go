@@ -19,6 +19,10 @@ func NewDB(dsn string) (*gorm.DB, error) {
 		&model.Market{},
 		&model.Price{},
 		&model.FundingRate{},
+		&model.MarketStats{},
+		&model.Candle{},
+		&model.Opportunity{},
+		&model.AlertRule{},
 	)
 	if err != nil {
 		return nil, err
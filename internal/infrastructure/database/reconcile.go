@@ -0,0 +1,99 @@
+package database
+
+import (
+	"btc-dex-dashboard/internal/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// ExchangeSpec は config.yaml で宣言した取引所とそのマーケット
+type ExchangeSpec struct {
+	Key         string
+	DisplayName string
+	Reference   bool
+	MakerFee    float64
+	TakerFee    float64
+	Markets     []MarketSpec
+}
+
+// MarketSpec は取引所のマーケット。取引所ごとに BaseAsset で一意
+type MarketSpec struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+}
+
+// Reconcile は取引所とマーケットを specs に合わせる。specs にあるものは作成・更新して有効にし、
+// ないものは Disabled にする（価格履歴を残すため削除はしない）
+func Reconcile(db *gorm.DB, specs []ExchangeSpec) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		exchangeIDs := make([]uint, 0, len(specs))
+		for _, spec := range specs {
+			exchange := model.Exchange{Key: spec.Key}
+			if err := tx.Where("key = ?", spec.Key).
+				Attrs(model.Exchange{DisplayName: spec.DisplayName}).
+				FirstOrCreate(&exchange).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&exchange).Updates(map[string]interface{}{
+				"display_name": spec.DisplayName,
+				"reference":    spec.Reference,
+				"maker_fee":    spec.MakerFee,
+				"taker_fee":    spec.TakerFee,
+				"disabled":     false,
+			}).Error; err != nil {
+				return err
+			}
+			exchangeIDs = append(exchangeIDs, exchange.ID)
+
+			if err := reconcileMarkets(tx, exchange.ID, spec.Markets); err != nil {
+				return err
+			}
+		}
+
+		// 設定から削除された取引所はマーケットごと無効にする
+		removed := tx.Model(&model.Exchange{})
+		if len(exchangeIDs) > 0 {
+			removed = removed.Where("id NOT IN ?", exchangeIDs)
+		}
+		var removedIDs []uint
+		if err := removed.Pluck("id", &removedIDs).Error; err != nil {
+			return err
+		}
+		if len(removedIDs) == 0 {
+			return nil
+		}
+		if err := tx.Model(&model.Exchange{}).Where("id IN ?", removedIDs).
+			Update("disabled", true).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Market{}).Where("exchange_id IN ?", removedIDs).
+			Update("disabled", true).Error
+	})
+}
+
+func reconcileMarkets(tx *gorm.DB, exchangeID uint, specs []MarketSpec) error {
+	assets := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		market := model.Market{ExchangeID: exchangeID, BaseAsset: spec.BaseAsset}
+		if err := tx.Where("exchange_id = ? AND base_asset = ?", exchangeID, spec.BaseAsset).
+			Attrs(model.Market{Symbol: spec.Symbol, QuoteAsset: spec.QuoteAsset}).
+			FirstOrCreate(&market).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&market).Updates(map[string]interface{}{
+			"symbol":      spec.Symbol,
+			"quote_asset": spec.QuoteAsset,
+			"disabled":    false,
+		}).Error; err != nil {
+			return err
+		}
+		assets = append(assets, spec.BaseAsset)
+	}
+
+	removed := tx.Model(&model.Market{}).Where("exchange_id = ?", exchangeID)
+	if len(assets) > 0 {
+		removed = removed.Where("base_asset NOT IN ?", assets)
+	}
+	return removed.Update("disabled", true).Error
+}
@@ -9,22 +9,42 @@ import (
 	"time"
 )
 
-const asterAPIURL = "https://fapi.asterdex.com/fapi/v1/ticker/bookTicker"
+const (
+	asterBaseURL          = "https://fapi.asterdex.com"
+	asterBookTickerPath   = "/fapi/v1/ticker/bookTicker"
+	asterPremiumIndexPath = "/fapi/v1/premiumIndex"
+	asterDepthPath        = "/fapi/v1/depth"
+	asterTicker24hrPath   = "/fapi/v1/ticker/24hr"
+	asterOpenInterestPath = "/fapi/v1/openInterest"
+
+	// asterFundingIntervalHours は Aster の Funding 精算間隔（時間）
+	asterFundingIntervalHours = 8
+)
 
 type AsterClient struct {
+	name       string
+	baseURL    string
+	streamURL  string
 	httpClient *http.Client
 }
 
-func NewAsterClient() *AsterClient {
+func NewAsterClient(opts ...Option) *AsterClient {
+	o := newClientOptions("aster", asterBaseURL, asterWSURL, opts)
 	return &AsterClient{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		name:       o.name,
+		baseURL:    o.baseURL,
+		streamURL:  o.streamURL,
+		httpClient: newHTTPClient(o),
 	}
 }
 
 func (c *AsterClient) Name() string {
-	return "aster"
+	return c.name
+}
+
+// Symbol は USDT 建ての無期限先物のシンボル（BTCUSDT など）を返す
+func (c *AsterClient) Symbol(asset string) string {
+	return asset + "USDT"
 }
 
 type asterBookTickerResponse struct {
@@ -36,14 +56,15 @@ type asterBookTickerResponse struct {
 	Time     int64  `json:"time"`
 }
 
-func (c *AsterClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData, error) {
-	url := asterAPIURL + "?symbol=BTCUSDT"
+func (c *AsterClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	url := c.baseURL + asterBookTickerPath + "?symbol=" + symbol
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "book_ticker"), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -69,14 +90,188 @@ func (c *AsterClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData, error)
 		return nil, fmt.Errorf("failed to parse ask price: %w", err)
 	}
 
+	receivedAt := time.Now()
 	return &PriceData{
-		Bid: bid,
-		Ask: ask,
-		Ts:  time.Now(),
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: msToTime(tickerResp.Time),
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+// asterDepthLimits は depth API が受け付ける limit の値
+var asterDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000}
+
+type asterDepthResponse struct {
+	LastUpdateID int64      `json:"lastUpdateId"`
+	Time         int64      `json:"T"`
+	Bids         [][]string `json:"bids"`
+	Asks         [][]string `json:"asks"`
+}
+
+func (c *AsterClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	// depth 以上で最小の limit を選ぶ
+	limit := asterDepthLimits[len(asterDepthLimits)-1]
+	for _, l := range asterDepthLimits {
+		if l >= depth {
+			limit = l
+			break
+		}
+	}
+	url := fmt.Sprintf("%s%s?symbol=%s&limit=%d", c.baseURL, asterDepthPath, symbol, limit)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "depth"), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var depthResp asterDepthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&depthResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	bids, err := parseStringLevels(depthResp.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseStringLevels(depthResp.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// parseStringLevels は ["価格", "数量"] 形式の配列を変換する
+func parseStringLevels(levels [][]string, depth int) ([]OrderBookLevel, error) {
+	if len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("invalid level: %v", l)
+		}
+		px, err := strconv.ParseFloat(l[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		sz, err := strconv.ParseFloat(l[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+type asterPremiumIndexResponse struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	Time            int64  `json:"time"`
+}
+
+// get は GET リクエストを送り、レスポンスを v にデコードする
+func (c *AsterClient) get(ctx context.Context, endpoint, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *AsterClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	var indexResp asterPremiumIndexResponse
+	if err := c.get(ctx, "premium_index", c.baseURL+asterPremiumIndexPath+"?symbol="+symbol, &indexResp); err != nil {
+		return nil, err
+	}
+
+	rate, err := strconv.ParseFloat(indexResp.LastFundingRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	// 8時間ごとのレートを1時間あたりに正規化
+	return &FundingRateData{
+		Rate: rate / asterFundingIntervalHours,
+		Ts:   time.Now(),
 	}, nil
 }
 
-func (c *AsterClient) FetchBTCPerpFundingRate(ctx context.Context) (*FundingRateData, error) {
-	// TODO: Funding Rate 取得を実装
-	return nil, fmt.Errorf("not implemented")
+type asterTicker24hrResponse struct {
+	Symbol      string `json:"symbol"`
+	Volume      string `json:"volume"`
+	QuoteVolume string `json:"quoteVolume"`
+}
+
+type asterOpenInterestResponse struct {
+	Symbol       string `json:"symbol"`
+	OpenInterest string `json:"openInterest"`
+	Time         int64  `json:"time"`
+}
+
+// FetchMarketStats は premiumIndex・ticker/24hr・openInterest の3つの API から統計を組み立てる
+func (c *AsterClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	var indexResp asterPremiumIndexResponse
+	if err := c.get(ctx, "premium_index", c.baseURL+asterPremiumIndexPath+"?symbol="+symbol, &indexResp); err != nil {
+		return nil, err
+	}
+	var tickerResp asterTicker24hrResponse
+	if err := c.get(ctx, "ticker_24hr", c.baseURL+asterTicker24hrPath+"?symbol="+symbol, &tickerResp); err != nil {
+		return nil, err
+	}
+	var oiResp asterOpenInterestResponse
+	if err := c.get(ctx, "open_interest", c.baseURL+asterOpenInterestPath+"?symbol="+symbol, &oiResp); err != nil {
+		return nil, err
+	}
+
+	var err error
+	stats := &MarketStats{Ts: time.Now()}
+	if stats.MarkPrice, err = parseOptionalFloat(indexResp.MarkPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	if stats.IndexPrice, err = parseOptionalFloat(indexResp.IndexPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse index price: %w", err)
+	}
+	if stats.OpenInterest, err = parseOptionalFloat(oiResp.OpenInterest); err != nil {
+		return nil, fmt.Errorf("failed to parse open interest: %w", err)
+	}
+	if stats.Volume24h, err = parseOptionalFloat(tickerResp.QuoteVolume); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return stats, nil
 }
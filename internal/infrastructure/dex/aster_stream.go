@@ -0,0 +1,83 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const asterWSURL = "wss://fstream.asterdex.com/ws"
+
+// NewAsterStreamingClient は symbols の bookTicker ストリームを購読する StreamingClient を返す
+func NewAsterStreamingClient(rest *AsterClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+	return newStreamingClient(rest, &asterStream{url: rest.streamURL}, symbols, staleAfter)
+}
+
+type asterStream struct {
+	url string
+}
+
+type asterBookTickerEvent struct {
+	EventType string `json:"e"`
+	Symbol    string `json:"s"`
+	BidPrice  string `json:"b"`
+	BidQty    string `json:"B"`
+	AskPrice  string `json:"a"`
+	AskQty    string `json:"A"`
+	Time      int64  `json:"T"`
+}
+
+func (s *asterStream) endpoint() string {
+	return s.url
+}
+
+func (s *asterStream) subscriptions(ctx context.Context, symbols []string) ([]interface{}, error) {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, strings.ToLower(symbol)+"@bookTicker")
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"method": "SUBSCRIBE",
+			"params": params,
+			"id":     1,
+		},
+	}, nil
+}
+
+// ping はサーバーからの ping フレームに自動で応答するため不要
+func (s *asterStream) ping() interface{} {
+	return nil
+}
+
+func (s *asterStream) reset() {}
+
+func (s *asterStream) handle(msg []byte) (string, *PriceData, error) {
+	var event asterBookTickerEvent
+	if err := decodeStreamMessage(msg, &event); err != nil {
+		return "", nil, err
+	}
+	// 購読の応答（{"result":null,"id":1}）などは無視する
+	if event.EventType != "bookTicker" {
+		return "", nil, nil
+	}
+
+	bid, err := strconv.ParseFloat(event.BidPrice, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(event.AskPrice, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	return event.Symbol, &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         time.Now(),
+		ExchangeTs: msToTime(event.Time),
+	}, nil
+}
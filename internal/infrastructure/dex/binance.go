@@ -0,0 +1,13 @@
+package dex
+
+const (
+	binanceBaseURL = "https://fapi.binance.com"
+	binanceWSURL   = "wss://fstream.binance.com/ws"
+)
+
+// NewBinanceClient は Binance の USDⓈ-M 無期限先物のクライアントを返す。
+// Aster の API は Binance と同じ形式（パス・レスポンス・WebSocket）のため AsterClient の実装を使う
+func NewBinanceClient(opts ...Option) *AsterClient {
+	defaults := []Option{WithName("binance"), WithBaseURL(binanceBaseURL), WithStreamURL(binanceWSURL)}
+	return NewAsterClient(append(defaults, opts...)...)
+}
@@ -0,0 +1,218 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	bybitBaseURL       = "https://api.bybit.com"
+	bybitTickersPath   = "/v5/market/tickers"
+	bybitOrderBookPath = "/v5/market/orderbook"
+
+	// bybitFundingIntervalHours は Bybit の主要銘柄の Funding 精算間隔（時間）
+	bybitFundingIntervalHours = 8
+	// bybitMaxDepth は orderbook API が受け付ける limit の上限
+	bybitMaxDepth = 500
+)
+
+// BybitClient は Bybit v5 の USDT 建て無期限先物（category=linear）
+type BybitClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewBybitClient(opts ...Option) *BybitClient {
+	o := newClientOptions("bybit", bybitBaseURL, "", opts)
+	return &BybitClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		httpClient: newHTTPClient(o),
+	}
+}
+
+func (c *BybitClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTCUSDT 形式のシンボルを返す
+func (c *BybitClient) Symbol(asset string) string {
+	return asset + "USDT"
+}
+
+// bybitResponse は v5 API の共通の形式
+type bybitResponse struct {
+	RetCode int             `json:"retCode"`
+	RetMsg  string          `json:"retMsg"`
+	Result  json.RawMessage `json:"result"`
+	Time    int64           `json:"time"`
+}
+
+// get は v5 API を呼び出し、result を v にデコードする。レスポンスの時刻を返す
+func (c *BybitClient) get(ctx context.Context, endpoint, path string, query url.Values, v interface{}) (int64, error) {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), "GET", c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var bybitResp bybitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bybitResp); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if bybitResp.RetCode != 0 {
+		return 0, fmt.Errorf("api error %d: %s", bybitResp.RetCode, bybitResp.RetMsg)
+	}
+	if err := json.Unmarshal(bybitResp.Result, v); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return bybitResp.Time, nil
+}
+
+type bybitTickersResult struct {
+	Category string        `json:"category"`
+	List     []bybitTicker `json:"list"`
+}
+
+type bybitTicker struct {
+	Symbol       string `json:"symbol"`
+	Bid1Price    string `json:"bid1Price"`
+	Bid1Size     string `json:"bid1Size"`
+	Ask1Price    string `json:"ask1Price"`
+	Ask1Size     string `json:"ask1Size"`
+	MarkPrice    string `json:"markPrice"`
+	IndexPrice   string `json:"indexPrice"`
+	FundingRate  string `json:"fundingRate"`
+	OpenInterest string `json:"openInterest"`
+	Turnover24h  string `json:"turnover24h"`
+}
+
+func (c *BybitClient) fetchTicker(ctx context.Context, symbol string) (*bybitTicker, int64, error) {
+	var result bybitTickersResult
+	ts, err := c.get(ctx, "tickers", bybitTickersPath, url.Values{"category": {"linear"}, "symbol": {symbol}}, &result)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, t := range result.List {
+		if t.Symbol == symbol {
+			return &result.List[i], ts, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("invalid response: %s not found", symbol)
+}
+
+func (c *BybitClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	start := time.Now()
+	ticker, ts, err := c.fetchTicker(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	bid, err := strconv.ParseFloat(ticker.Bid1Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(ticker.Ask1Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: msToTime(ts),
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+type bybitOrderBookResult struct {
+	Symbol string     `json:"s"`
+	Bids   [][]string `json:"b"`
+	Asks   [][]string `json:"a"`
+	Ts     int64      `json:"ts"`
+}
+
+func (c *BybitClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	limit := min(max(depth, 1), bybitMaxDepth)
+
+	var result bybitOrderBookResult
+	query := url.Values{"category": {"linear"}, "symbol": {symbol}, "limit": {strconv.Itoa(limit)}}
+	if _, err := c.get(ctx, "orderbook", bybitOrderBookPath, query, &result); err != nil {
+		return nil, err
+	}
+
+	bids, err := parseStringLevels(result.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseStringLevels(result.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+func (c *BybitClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	ticker, _, err := c.fetchTicker(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := strconv.ParseFloat(ticker.FundingRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	// 8時間ごとのレートを1時間あたりに正規化
+	return &FundingRateData{
+		Rate: rate / bybitFundingIntervalHours,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// FetchMarketStats はマーク価格・インデックス価格・建玉・24時間の売買代金を返す
+func (c *BybitClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	ticker, _, err := c.fetchTicker(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MarketStats{Ts: time.Now()}
+	if stats.MarkPrice, err = parseOptionalFloat(ticker.MarkPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	if stats.IndexPrice, err = parseOptionalFloat(ticker.IndexPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse index price: %w", err)
+	}
+	if stats.OpenInterest, err = parseOptionalFloat(ticker.OpenInterest); err != nil {
+		return nil, fmt.Errorf("failed to parse open interest: %w", err)
+	}
+	if stats.Volume24h, err = parseOptionalFloat(ticker.Turnover24h); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return stats, nil
+}
@@ -2,22 +2,105 @@ package dex
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// PriceData は最良気配。Ts はローカルでの受信時刻、ExchangeTs は取引所が付与した時刻
+// （取引所が返さない場合はゼロ値）、Latency は REST リクエストの往復時間（WebSocket では0）。
+// Cached は WebSocket で受信済みの気配値を返したもの（通信していないため Latency は計測値ではない）
 type PriceData struct {
-	Bid float64
-	Ask float64
-	Ts  time.Time
+	Bid        float64
+	Ask        float64
+	Ts         time.Time
+	ExchangeTs time.Time
+	Latency    time.Duration
+	Cached     bool
 }
 
+// FundingRateData は Funding Rate。Rate は取引所間で比較できるよう1時間あたりに正規化する
 type FundingRateData struct {
 	Rate float64
 	Ts   time.Time
 }
 
+// OrderBookLevel は板の1段（価格と数量）
+type OrderBookLevel struct {
+	Price float64
+	Size  float64
+}
+
+// OrderBook は板のスナップショット。Bids は価格の高い順、Asks は価格の安い順
+type OrderBook struct {
+	Bids []OrderBookLevel
+	Asks []OrderBookLevel
+	Ts   time.Time
+}
+
+// DexClient は取引所の無期限先物の API。symbol は取引所ごとのシンボル（BTC / BTC-PERP / BTCUSDT など）
 type DexClient interface {
 	Name() string
-	FetchBTCPerpPrice(ctx context.Context) (*PriceData, error)
-	FetchBTCPerpFundingRate(ctx context.Context) (*FundingRateData, error)
+	// Symbol は銘柄（BTC など）に対応する取引所のシンボルを返す
+	Symbol(asset string) string
+	FetchPrice(ctx context.Context, symbol string) (*PriceData, error)
+	FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error)
+	FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error)
+}
+
+// MarketStats はマーケットの統計。取引所が返さない項目は nil。
+// OpenInterest は基軸通貨建ての建玉、Volume24h は直近24時間の売買代金（クォート通貨建て）
+type MarketStats struct {
+	MarkPrice    *float64
+	IndexPrice   *float64
+	OpenInterest *float64
+	Volume24h    *float64
+	Ts           time.Time
+}
+
+// MarketStatsClient はマーク価格・インデックス価格・建玉・出来高を取得できる取引所の API
+type MarketStatsClient interface {
+	FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error)
+}
+
+// msToTime はミリ秒の UNIX 時刻を time.Time に変換する。0 はゼロ値にする
+func msToTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// parseFixedPoint は 10^decimals 倍の整数の文字列を小数に変換する。
+// float64 で割ると丸め誤差が出るため小数点を文字列で挿入してからパースする
+func parseFixedPoint(s string, decimals int) (float64, error) {
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	if digits == "" {
+		return 0, fmt.Errorf("invalid fixed-point value: %q", s)
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals+1-len(digits)) + digits
+	}
+	v, err := strconv.ParseFloat(digits[:len(digits)-decimals]+"."+digits[len(digits)-decimals:], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fixed-point value: %q", s)
+	}
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+// parseOptionalFloat は数値の文字列をパースする。空文字列は nil（取引所が値を返さない）
+func parseOptionalFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
 }
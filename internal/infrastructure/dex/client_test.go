@@ -0,0 +1,74 @@
+package dex
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newPayloadServer はパスごとに固定のレスポンスを返すテスト用サーバー。クエリは無視する
+func newPayloadServer(t *testing.T, payloads map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := payloads[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testTransport は再試行・ブレーカーなしで1回だけ通信する
+var testTransport = WithTransportConfig(TransportConfig{Timeout: 5 * time.Second})
+
+// almostEqual は浮動小数点の丸め誤差を許容して比較する
+func almostEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= 1e-12*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestParseFixedPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		decimals int
+		want     float64
+		wantErr  bool
+	}{
+		{name: "integer part", s: "106812345678", decimals: 6, want: 106812.345678},
+		{name: "shorter than decimals", s: "5", decimals: 6, want: 0.000005},
+		{name: "same length as decimals", s: "500000", decimals: 6, want: 0.5},
+		{name: "negative", s: "-31250", decimals: 9, want: -0.00003125},
+		{name: "zero", s: "0", decimals: 18, want: 0},
+		{name: "empty", s: "", decimals: 6, wantErr: true},
+		{name: "sign only", s: "-", decimals: 6, wantErr: true},
+		{name: "decimal string", s: "1.5", decimals: 6, wantErr: true},
+		{name: "not a number", s: "abc", decimals: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFixedPoint(tt.s, tt.decimals)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseFixedPoint(%q, %d) = %v, want error", tt.s, tt.decimals, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFixedPoint(%q, %d): %v", tt.s, tt.decimals, err)
+			}
+			if !almostEqual(got, tt.want) {
+				t.Errorf("parseFixedPoint(%q, %d) = %v, want %v", tt.s, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
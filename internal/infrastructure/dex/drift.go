@@ -0,0 +1,239 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	driftBaseURL          = "https://dlob.drift.trade"
+	driftDataURL          = "https://data.api.drift.trade"
+	driftL2Path           = "/l2"
+	driftFundingRatesPath = "/fundingRates"
+
+	// driftSymbolSuffix は Drift の無期限先物のシンボルの接尾辞（BTC-PERP など）
+	driftSymbolSuffix = "-PERP"
+
+	// Drift の固定小数点の桁数（価格は 1e6、数量は 1e9、Funding Rate は 1e9 倍の整数）
+	driftPriceDecimals       = 6
+	driftBaseDecimals        = 9
+	driftFundingRateDecimals = 9
+)
+
+// DriftClient は Drift の DLOB サーバー（板）と data API（Funding）。Funding は1時間ごとに精算される
+type DriftClient struct {
+	name       string
+	baseURL    string
+	dataURL    string
+	httpClient *http.Client
+}
+
+func NewDriftClient(opts ...Option) *DriftClient {
+	o := newClientOptions("drift", driftBaseURL, "", append([]Option{WithDataURL(driftDataURL)}, opts...))
+	return &DriftClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		dataURL:    o.dataURL,
+		httpClient: newHTTPClient(o),
+	}
+}
+
+func (c *DriftClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTC-PERP 形式のシンボルを返す
+func (c *DriftClient) Symbol(asset string) string {
+	return asset + driftSymbolSuffix
+}
+
+type driftL2Response struct {
+	MarketName string         `json:"marketName"`
+	Bids       []driftL2Level `json:"bids"`
+	Asks       []driftL2Level `json:"asks"`
+	Slot       int64          `json:"slot"`
+	Ts         json.Number    `json:"ts"`
+}
+
+type driftL2Level struct {
+	Price json.Number `json:"price"`
+	Size  json.Number `json:"size"`
+}
+
+// fetchL2 は vAMM の流動性を含む板を depth 段取得する
+func (c *DriftClient) fetchL2(ctx context.Context, symbol string, depth int) (*driftL2Response, error) {
+	u := fmt.Sprintf("%s%s?marketName=%s&depth=%d&includeVamm=true", c.baseURL, driftL2Path, url.QueryEscape(symbol), depth)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "l2"), "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var l2Resp driftL2Response
+	if err := json.NewDecoder(resp.Body).Decode(&l2Resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &l2Resp, nil
+}
+
+func (c *DriftClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	start := time.Now()
+	l2Resp, err := c.fetchL2(ctx, symbol, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l2Resp.Bids) == 0 || len(l2Resp.Asks) == 0 {
+		return nil, fmt.Errorf("invalid response: no bids or asks")
+	}
+
+	bid, err := parseFixedPoint(l2Resp.Bids[0].Price.String(), driftPriceDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := parseFixedPoint(l2Resp.Asks[0].Price.String(), driftPriceDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	var exchangeTs time.Time
+	if ts, err := l2Resp.Ts.Int64(); err == nil {
+		exchangeTs = msToTime(ts)
+	}
+
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: exchangeTs,
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+func (c *DriftClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	l2Resp, err := c.fetchL2(ctx, symbol, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := parseDriftLevels(l2Resp.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseDriftLevels(l2Resp.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+func parseDriftLevels(levels []driftL2Level, depth int) ([]OrderBookLevel, error) {
+	if len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		px, err := parseFixedPoint(l.Price.String(), driftPriceDecimals)
+		if err != nil {
+			return nil, err
+		}
+		sz, err := parseFixedPoint(l.Size.String(), driftBaseDecimals)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+type driftFundingRatesResponse struct {
+	FundingRates []driftFundingRate `json:"fundingRates"`
+}
+
+type driftFundingRate struct {
+	Ts              json.Number `json:"ts"`
+	FundingRate     json.Number `json:"fundingRate"`
+	OraclePriceTwap json.Number `json:"oraclePriceTwap"`
+	MarkPriceTwap   json.Number `json:"markPriceTwap"`
+}
+
+func (c *DriftClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	u := c.dataURL + driftFundingRatesPath + "?marketName=" + url.QueryEscape(symbol)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "funding_rates"), "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var ratesResp driftFundingRatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ratesResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// 精算履歴のうち最新のものを使う
+	var latest *driftFundingRate
+	var latestTs int64
+	for i, fr := range ratesResp.FundingRates {
+		ts, err := fr.Ts.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse funding time: %w", err)
+		}
+		if latest == nil || ts > latestTs {
+			latest, latestTs = &ratesResp.FundingRates[i], ts
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("invalid response: %s funding rate not found", symbol)
+	}
+
+	fundingRate, err := parseFixedPoint(latest.FundingRate.String(), driftFundingRateDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+	oracleTwap, err := parseFixedPoint(latest.OraclePriceTwap.String(), driftPriceDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oracle price: %w", err)
+	}
+	if oracleTwap == 0 {
+		return nil, fmt.Errorf("invalid response: zero oracle price")
+	}
+
+	// fundingRate は1時間・1枚あたりの USD。オラクル価格で割って比率にする
+	return &FundingRateData{
+		Rate: fundingRate / oracleTwap,
+		Ts:   time.Now(),
+	}, nil
+}
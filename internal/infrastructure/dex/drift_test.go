@@ -0,0 +1,92 @@
+package dex
+
+import (
+	"context"
+	"testing"
+)
+
+// driftL2Payload は DLOB サーバーの /l2?marketName=BTC-PERP&includeVamm=true の形式（価格 1e6、数量 1e9）
+const driftL2Payload = `{"bids":[{"price":"106809000000","size":"1520000000","sources":{"vamm":"1520000000"}},{"price":"106808500000","size":"250000000","sources":{"dlob":"250000000"}}],"asks":[{"price":"106810250000","size":"30000000","sources":{"dlob":"30000000"}},{"price":"106811000000","size":"2000000000","sources":{"vamm":"2000000000"}}],"marketName":"BTC-PERP","marketType":"perp","marketIndex":1,"ts":1760745601234,"slot":371234567,"oracle":106795123456}`
+
+func TestDriftFetchOrderBook(t *testing.T) {
+	srv := newPayloadServer(t, map[string]string{driftL2Path: driftL2Payload})
+	client := NewDriftClient(WithBaseURL(srv.URL), testTransport)
+
+	book, err := client.FetchOrderBook(context.Background(), "BTC-PERP", 2)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+
+	want := struct{ bids, asks []OrderBookLevel }{
+		bids: []OrderBookLevel{{Price: 106809, Size: 1.52}, {Price: 106808.5, Size: 0.25}},
+		asks: []OrderBookLevel{{Price: 106810.25, Size: 0.03}, {Price: 106811, Size: 2}},
+	}
+	for _, side := range []struct {
+		name      string
+		got, want []OrderBookLevel
+	}{
+		{"bids", book.Bids, want.bids},
+		{"asks", book.Asks, want.asks},
+	} {
+		if len(side.got) != len(side.want) {
+			t.Fatalf("%s = %v, want %v", side.name, side.got, side.want)
+		}
+		for i := range side.want {
+			if !almostEqual(side.got[i].Price, side.want[i].Price) || !almostEqual(side.got[i].Size, side.want[i].Size) {
+				t.Errorf("%s[%d] = %+v, want %+v", side.name, i, side.got[i], side.want[i])
+			}
+		}
+	}
+}
+
+func TestDriftFetchFundingRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    float64
+		wantErr bool
+	}{
+		{
+			// fundingRate 1.067501234 USD / oracleTwap 106750.123456 USD
+			name:    "latest record",
+			payload: `{"success":true,"fundingRates":[{"slot":371224001,"ts":"1760738400","recordId":"51201","marketIndex":1,"fundingRate":"980000000","fundingRateLong":"980000000","fundingRateShort":"980000000","cumulativeFundingRateLong":"2419812331872","cumulativeFundingRateShort":"2419812331872","oraclePriceTwap":"107010000000","markPriceTwap":"107021000000","periodRevenue":"0","baseAssetAmountWithAmm":"-31250000000","baseAssetAmountWithUnsettledLp":"0"},{"slot":371232113,"ts":"1760742000","recordId":"51202","marketIndex":1,"fundingRate":"1067501234","fundingRateLong":"1067501234","fundingRateShort":"1067501234","cumulativeFundingRateLong":"2420879833106","cumulativeFundingRateShort":"2420879833106","oraclePriceTwap":"106750123456","markPriceTwap":"106761981002","periodRevenue":"0","baseAssetAmountWithAmm":"-31250000000","baseAssetAmountWithUnsettledLp":"0"}]}`,
+			want:    1.067501234 / 106750.123456,
+		},
+		{
+			name:    "negative rate",
+			payload: `{"success":true,"fundingRates":[{"slot":371232113,"ts":"1760742000","recordId":"51202","marketIndex":1,"fundingRate":"-213500000","oraclePriceTwap":"106750000000","markPriceTwap":"106728650000"}]}`,
+			want:    -0.2135 / 106750,
+		},
+		{
+			name:    "no records",
+			payload: `{"success":true,"fundingRates":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "zero oracle price",
+			payload: `{"success":true,"fundingRates":[{"ts":"1760742000","fundingRate":"1067501234","oraclePriceTwap":"0","markPriceTwap":"0"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPayloadServer(t, map[string]string{driftFundingRatesPath: tt.payload})
+			client := NewDriftClient(WithDataURL(srv.URL), testTransport)
+
+			got, err := client.FetchFundingRate(context.Background(), "BTC-PERP")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FetchFundingRate = %v, want error", got.Rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchFundingRate: %v", err)
+			}
+			if !almostEqual(got.Rate, tt.want) {
+				t.Errorf("rate = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
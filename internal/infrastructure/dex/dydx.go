@@ -0,0 +1,235 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	dydxBaseURL              = "https://indexer.dydx.trade/v4"
+	dydxOrderBookPath        = "/orderbooks/perpetualMarket/"
+	dydxPerpetualMarketsPath = "/perpetualMarkets"
+
+	// dydxSymbolSuffix は dYdX v4 のシンボルの接尾辞（BTC-USD など）
+	dydxSymbolSuffix = "-USD"
+)
+
+// DydxClient は dYdX v4 のインデクサー API。Funding は1時間ごとに精算される
+type DydxClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewDydxClient(opts ...Option) *DydxClient {
+	o := newClientOptions("dydx", dydxBaseURL, "", opts)
+	return &DydxClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		httpClient: newHTTPClient(o),
+	}
+}
+
+func (c *DydxClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTC-USD 形式のシンボルを返す
+func (c *DydxClient) Symbol(asset string) string {
+	return asset + dydxSymbolSuffix
+}
+
+type dydxOrderBookResponse struct {
+	Bids []dydxLevel `json:"bids"`
+	Asks []dydxLevel `json:"asks"`
+}
+
+type dydxLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// fetchOrderBook は板全体を取得する（インデクサーは段数を指定できない）
+func (c *DydxClient) fetchOrderBook(ctx context.Context, symbol string) (*dydxOrderBookResponse, error) {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "orderbook"), "GET", c.baseURL+dydxOrderBookPath+url.PathEscape(symbol), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var bookResp dydxOrderBookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bookResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &bookResp, nil
+}
+
+func (c *DydxClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	start := time.Now()
+	bookResp, err := c.fetchOrderBook(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bookResp.Bids) == 0 || len(bookResp.Asks) == 0 {
+		return nil, fmt.Errorf("invalid response: no bids or asks")
+	}
+
+	bid, err := strconv.ParseFloat(bookResp.Bids[0].Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(bookResp.Asks[0].Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	// インデクサーの板は時刻を返さないため ExchangeTs は設定しない
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:     bid,
+		Ask:     ask,
+		Ts:      receivedAt,
+		Latency: receivedAt.Sub(start),
+	}, nil
+}
+
+func (c *DydxClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	bookResp, err := c.fetchOrderBook(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := parseDydxLevels(bookResp.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseDydxLevels(bookResp.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+func parseDydxLevels(levels []dydxLevel, depth int) ([]OrderBookLevel, error) {
+	if len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		px, err := strconv.ParseFloat(l.Price, 64)
+		if err != nil {
+			return nil, err
+		}
+		sz, err := strconv.ParseFloat(l.Size, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+type dydxPerpetualMarketsResponse struct {
+	Markets map[string]dydxPerpetualMarket `json:"markets"`
+}
+
+type dydxPerpetualMarket struct {
+	Ticker          string `json:"ticker"`
+	Status          string `json:"status"`
+	OraclePrice     string `json:"oraclePrice"`
+	NextFundingRate string `json:"nextFundingRate"`
+	OpenInterest    string `json:"openInterest"`
+	Volume24H       string `json:"volume24H"`
+}
+
+func (c *DydxClient) fetchPerpetualMarket(ctx context.Context, symbol string) (*dydxPerpetualMarket, error) {
+	u := c.baseURL + dydxPerpetualMarketsPath + "?ticker=" + url.QueryEscape(symbol)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "perpetual_markets"), "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var marketsResp dydxPerpetualMarketsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&marketsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	market, ok := marketsResp.Markets[symbol]
+	if !ok {
+		return nil, fmt.Errorf("invalid response: %s not found", symbol)
+	}
+	return &market, nil
+}
+
+func (c *DydxClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	market, err := c.fetchPerpetualMarket(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// nextFundingRate は次の1時間分の予測レート
+	rate, err := strconv.ParseFloat(market.NextFundingRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	return &FundingRateData{
+		Rate: rate,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// FetchMarketStats はオラクル価格・建玉・24時間の売買代金を返す。
+// dYdX はオラクル価格で証拠金を評価するため独立したマーク価格はない
+func (c *DydxClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	market, err := c.fetchPerpetualMarket(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MarketStats{Ts: time.Now()}
+	if stats.IndexPrice, err = parseOptionalFloat(market.OraclePrice); err != nil {
+		return nil, fmt.Errorf("failed to parse oracle price: %w", err)
+	}
+	if stats.OpenInterest, err = parseOptionalFloat(market.OpenInterest); err != nil {
+		return nil, fmt.Errorf("failed to parse open interest: %w", err)
+	}
+	if stats.Volume24h, err = parseOptionalFloat(market.Volume24H); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,72 @@
+package dex
+
+import (
+	"context"
+	"testing"
+)
+
+// dydxMarketsPayload は /perpetualMarkets の形式。markets はティッカーをキーにしたオブジェクト
+const dydxMarketsPayload = `{"markets":{"BTC-USD":{"clobPairId":"0","ticker":"BTC-USD","status":"ACTIVE","oraclePrice":"106795.12841","priceChange24H":"-1290.41","volume24H":"412398273.5612","trades24H":58123,"nextFundingRate":"0.00000703125","initialMarginFraction":"0.02","maintenanceMarginFraction":"0.012","openInterest":"684.9521","atomicResolution":-10,"quantumConversionExponent":-9,"tickSize":"1","stepSize":"0.0001","stepBaseQuantums":1000000,"subticksPerTick":100000,"marketType":"CROSS","openInterestLowerCap":"0","openInterestUpperCap":"0","baseOpenInterest":"683.1","defaultFundingRate1H":"0"},"ETH-USD":{"clobPairId":"1","ticker":"ETH-USD","status":"ACTIVE","oraclePrice":"3888.71302","priceChange24H":"-81.2","volume24H":"198231234.8812","trades24H":41234,"nextFundingRate":"-0.0000018125","initialMarginFraction":"0.02","maintenanceMarginFraction":"0.012","openInterest":"21832.118","atomicResolution":-9,"quantumConversionExponent":-9,"tickSize":"0.1","stepSize":"0.001","stepBaseQuantums":1000000,"subticksPerTick":100000,"marketType":"CROSS","openInterestLowerCap":"0","openInterestUpperCap":"0","baseOpenInterest":"21790.2","defaultFundingRate1H":"0"}}}`
+
+func TestDydxFetchFundingRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbol  string
+		want    float64
+		wantErr bool
+	}{
+		// nextFundingRate は1時間あたりのレートなので正規化しない
+		{name: "BTC", symbol: "BTC-USD", want: 0.00000703125},
+		{name: "ETH", symbol: "ETH-USD", want: -0.0000018125},
+		{name: "ticker not in markets", symbol: "SOL-USD", wantErr: true},
+		{name: "ticker is case sensitive", symbol: "btc-usd", wantErr: true},
+	}
+
+	srv := newPayloadServer(t, map[string]string{dydxPerpetualMarketsPath: dydxMarketsPayload})
+	client := NewDydxClient(WithBaseURL(srv.URL), testTransport)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.FetchFundingRate(context.Background(), tt.symbol)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FetchFundingRate(%s) = %v, want error", tt.symbol, got.Rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchFundingRate(%s): %v", tt.symbol, err)
+			}
+			if got.Rate != tt.want {
+				t.Errorf("rate = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
+
+func TestDydxFetchMarketStats(t *testing.T) {
+	srv := newPayloadServer(t, map[string]string{dydxPerpetualMarketsPath: dydxMarketsPayload})
+	client := NewDydxClient(WithBaseURL(srv.URL), testTransport)
+
+	stats, err := client.FetchMarketStats(context.Background(), "ETH-USD")
+	if err != nil {
+		t.Fatalf("FetchMarketStats: %v", err)
+	}
+
+	if stats.MarkPrice != nil {
+		t.Errorf("mark price = %v, want nil", *stats.MarkPrice)
+	}
+	for _, f := range []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"index price", stats.IndexPrice, 3888.71302},
+		{"open interest", stats.OpenInterest, 21832.118},
+		{"volume", stats.Volume24h, 198231234.8812},
+	} {
+		if f.got == nil || *f.got != f.want {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+}
@@ -10,22 +10,35 @@ import (
 	"time"
 )
 
-const hyperliquidAPIURL = "https://api.hyperliquid.xyz/info"
+const (
+	hyperliquidBaseURL  = "https://api.hyperliquid.xyz"
+	hyperliquidInfoPath = "/info"
+)
 
 type HyperliquidClient struct {
+	name       string
+	baseURL    string
+	streamURL  string
 	httpClient *http.Client
 }
 
-func NewHyperliquidClient() *HyperliquidClient {
+func NewHyperliquidClient(opts ...Option) *HyperliquidClient {
+	o := newClientOptions("hyperliquid", hyperliquidBaseURL, hyperliquidWSURL, opts)
 	return &HyperliquidClient{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		name:       o.name,
+		baseURL:    o.baseURL,
+		streamURL:  o.streamURL,
+		httpClient: newHTTPClient(o),
 	}
 }
 
 func (c *HyperliquidClient) Name() string {
-	return "hyperliquid"
+	return c.name
+}
+
+// Symbol は銘柄名をそのまま coin として使う
+func (c *HyperliquidClient) Symbol(asset string) string {
+	return asset
 }
 
 type hyperliquidL2Request struct {
@@ -45,10 +58,10 @@ type hyperliquidLevel struct {
 	N  int    `json:"n"`
 }
 
-func (c *HyperliquidClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData, error) {
+func (c *HyperliquidClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
 	reqBody := hyperliquidL2Request{
 		Type: "l2Book",
-		Coin: "BTC",
+		Coin: symbol,
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -56,12 +69,13 @@ func (c *HyperliquidClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData,
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", hyperliquidAPIURL, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "l2_book"), "POST", c.baseURL+hyperliquidInfoPath, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -91,14 +105,204 @@ func (c *HyperliquidClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData,
 		return nil, fmt.Errorf("failed to parse ask price: %w", err)
 	}
 
+	receivedAt := time.Now()
 	return &PriceData{
-		Bid: bid,
-		Ask: ask,
-		Ts:  time.Now(),
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: msToTime(l2Resp.Time),
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+// FetchOrderBook は l2Book から最大 depth 段の板を取得する（API の上限は片側20段）
+func (c *HyperliquidClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	reqBody := hyperliquidL2Request{
+		Type: "l2Book",
+		Coin: symbol,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "l2_book"), "POST", c.baseURL+hyperliquidInfoPath, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var l2Resp hyperliquidL2Response
+	if err := json.NewDecoder(resp.Body).Decode(&l2Resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(l2Resp.Levels) < 2 {
+		return nil, fmt.Errorf("invalid response: insufficient levels")
+	}
+
+	bids, err := parseHyperliquidLevels(l2Resp.Levels[0], depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseHyperliquidLevels(l2Resp.Levels[1], depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+func parseHyperliquidLevels(levels []hyperliquidLevel, depth int) ([]OrderBookLevel, error) {
+	if len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		px, err := strconv.ParseFloat(l.Px, 64)
+		if err != nil {
+			return nil, err
+		}
+		sz, err := strconv.ParseFloat(l.Sz, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+type hyperliquidInfoRequest struct {
+	Type string `json:"type"`
+}
+
+type hyperliquidMeta struct {
+	Universe []hyperliquidAsset `json:"universe"`
+}
+
+type hyperliquidAsset struct {
+	Name string `json:"name"`
+}
+
+type hyperliquidAssetCtx struct {
+	Funding      string `json:"funding"`
+	MarkPx       string `json:"markPx"`
+	OraclePx     string `json:"oraclePx"`
+	OpenInterest string `json:"openInterest"`
+	DayNtlVlm    string `json:"dayNtlVlm"`
+}
+
+// fetchAssetCtx は metaAndAssetCtxs から銘柄のコンテキスト（Funding・マーク価格・建玉など）を取り出す
+func (c *HyperliquidClient) fetchAssetCtx(ctx context.Context, symbol string) (*hyperliquidAssetCtx, error) {
+	reqBody := hyperliquidInfoRequest{
+		Type: "metaAndAssetCtxs",
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "meta_and_asset_ctxs"), "POST", c.baseURL+hyperliquidInfoPath, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// レスポンスは [meta, assetCtxs] の2要素配列
+	var raw []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(raw) < 2 {
+		return nil, fmt.Errorf("invalid response: expected [meta, assetCtxs]")
+	}
+
+	var meta hyperliquidMeta
+	if err := json.Unmarshal(raw[0], &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode meta: %w", err)
+	}
+
+	var assetCtxs []hyperliquidAssetCtx
+	if err := json.Unmarshal(raw[1], &assetCtxs); err != nil {
+		return nil, fmt.Errorf("failed to decode asset contexts: %w", err)
+	}
+
+	// universe と assetCtxs は同じ順序で並んでいる
+	for i, asset := range meta.Universe {
+		if asset.Name != symbol || i >= len(assetCtxs) {
+			continue
+		}
+		return &assetCtxs[i], nil
+	}
+
+	return nil, fmt.Errorf("invalid response: %s not found in universe", symbol)
+}
+
+func (c *HyperliquidClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	assetCtx, err := c.fetchAssetCtx(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hyperliquid の funding は1時間ごとのレート
+	rate, err := strconv.ParseFloat(assetCtx.Funding, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	return &FundingRateData{
+		Rate: rate,
+		Ts:   time.Now(),
 	}, nil
 }
 
-func (c *HyperliquidClient) FetchBTCPerpFundingRate(ctx context.Context) (*FundingRateData, error) {
-	// TODO: Funding Rate 取得を実装
-	return nil, fmt.Errorf("not implemented")
+// FetchMarketStats はマーク価格・オラクル価格・建玉（枚数）・24時間の売買代金を返す
+func (c *HyperliquidClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	assetCtx, err := c.fetchAssetCtx(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MarketStats{Ts: time.Now()}
+	if stats.MarkPrice, err = parseOptionalFloat(assetCtx.MarkPx); err != nil {
+		return nil, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	if stats.IndexPrice, err = parseOptionalFloat(assetCtx.OraclePx); err != nil {
+		return nil, fmt.Errorf("failed to parse oracle price: %w", err)
+	}
+	if stats.OpenInterest, err = parseOptionalFloat(assetCtx.OpenInterest); err != nil {
+		return nil, fmt.Errorf("failed to parse open interest: %w", err)
+	}
+	if stats.Volume24h, err = parseOptionalFloat(assetCtx.DayNtlVlm); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return stats, nil
 }
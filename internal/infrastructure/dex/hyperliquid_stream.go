@@ -0,0 +1,80 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const hyperliquidWSURL = "wss://api.hyperliquid.xyz/ws"
+
+// NewHyperliquidStreamingClient は symbols の l2Book チャンネルを購読する StreamingClient を返す
+func NewHyperliquidStreamingClient(rest *HyperliquidClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+	return newStreamingClient(rest, &hyperliquidStream{url: rest.streamURL}, symbols, staleAfter)
+}
+
+type hyperliquidStream struct {
+	url string
+}
+
+type hyperliquidWSMessage struct {
+	Channel string                `json:"channel"`
+	Data    hyperliquidL2Response `json:"data"`
+}
+
+func (s *hyperliquidStream) endpoint() string {
+	return s.url
+}
+
+func (s *hyperliquidStream) subscriptions(ctx context.Context, symbols []string) ([]interface{}, error) {
+	subs := make([]interface{}, 0, len(symbols))
+	for _, symbol := range symbols {
+		subs = append(subs, map[string]interface{}{
+			"method": "subscribe",
+			"subscription": map[string]string{
+				"type": "l2Book",
+				"coin": symbol,
+			},
+		})
+	}
+	return subs, nil
+}
+
+func (s *hyperliquidStream) ping() interface{} {
+	return map[string]string{"method": "ping"}
+}
+
+func (s *hyperliquidStream) reset() {}
+
+func (s *hyperliquidStream) handle(msg []byte) (string, *PriceData, error) {
+	var wsMsg hyperliquidWSMessage
+	if err := decodeStreamMessage(msg, &wsMsg); err != nil {
+		return "", nil, err
+	}
+	if wsMsg.Channel != "l2Book" {
+		return "", nil, nil
+	}
+
+	levels := wsMsg.Data.Levels
+	if len(levels) < 2 || len(levels[0]) == 0 || len(levels[1]) == 0 {
+		return "", nil, fmt.Errorf("invalid message: insufficient levels")
+	}
+
+	bid, err := strconv.ParseFloat(levels[0][0].Px, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(levels[1][0].Px, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	return wsMsg.Data.Coin, &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         time.Now(),
+		ExchangeTs: msToTime(wsMsg.Data.Time),
+	}, nil
+}
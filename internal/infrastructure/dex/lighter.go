@@ -6,25 +6,125 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
-const lighterAPIURL = "https://mainnet.zklighter.elliot.ai/api/v1/orderBookOrders"
+const (
+	lighterBaseURL              = "https://mainnet.zklighter.elliot.ai"
+	lighterOrderBookOrdersPath  = "/api/v1/orderBookOrders"
+	lighterFundingRatesPath     = "/api/v1/funding-rates"
+	lighterOrderBooksPath       = "/api/v1/orderBooks"
+	lighterOrderBookDetailsPath = "/api/v1/orderBookDetails"
+
+	// lighterSymbolSuffix は Lighter のシンボルの接尾辞（BTC-PERP など）
+	lighterSymbolSuffix = "-PERP"
+
+	// lighterFundingIntervalHours は funding-rates API が返すレートの期間（時間）。
+	// Lighter の Funding は1時間ごとに精算され、1時間のレートは8時間レートの 1/8
+	// （https://docs.lighter.xyz/perpetual-futures/funding）。funding-rates API
+	// （https://apidocs.lighter.xyz/reference/funding-rates）は Binance・Bybit などと比較するため
+	// 8時間あたりに揃えたレートを返すとドキュメントから判断した。本番のレスポンスでは未確認で、
+	// 同じレスポンスの Binance の行と BinanceClient のレートを比べる TestLiveMarketData で確かめる
+	lighterFundingIntervalHours = 8
+)
+
+// lighterKnownMarketIDs は主要銘柄の market_id。これ以外は orderBooks API で引く
+var lighterKnownMarketIDs = map[string]int{
+	"ETH": 0,
+	"BTC": 1,
+	"SOL": 2,
+}
 
 type LighterClient struct {
+	name       string
+	baseURL    string
+	streamURL  string
 	httpClient *http.Client
+
+	mu        sync.Mutex
+	marketIDs map[string]int // 銘柄 → market_id
 }
 
-func NewLighterClient() *LighterClient {
+func NewLighterClient(opts ...Option) *LighterClient {
+	o := newClientOptions("lighter", lighterBaseURL, lighterWSURL, opts)
+	marketIDs := make(map[string]int, len(lighterKnownMarketIDs))
+	for asset, id := range lighterKnownMarketIDs {
+		marketIDs[asset] = id
+	}
 	return &LighterClient{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		name:       o.name,
+		baseURL:    o.baseURL,
+		streamURL:  o.streamURL,
+		httpClient: newHTTPClient(o),
+		marketIDs:  marketIDs,
 	}
 }
 
 func (c *LighterClient) Name() string {
-	return "lighter"
+	return c.name
+}
+
+// Symbol は BTC-PERP 形式のシンボルを返す
+func (c *LighterClient) Symbol(asset string) string {
+	return asset + lighterSymbolSuffix
+}
+
+type lighterOrderBooksResponse struct {
+	Code       int                    `json:"code"`
+	OrderBooks []lighterOrderBookMeta `json:"order_books"`
+}
+
+type lighterOrderBookMeta struct {
+	Symbol   string `json:"symbol"`
+	MarketID int    `json:"market_id"`
+}
+
+// marketID はシンボルを market_id に変換する。数値のシンボルはそのまま market_id とみなす
+func (c *LighterClient) marketID(ctx context.Context, symbol string) (int, error) {
+	if id, err := strconv.Atoi(symbol); err == nil {
+		return id, nil
+	}
+	asset := strings.TrimSuffix(symbol, lighterSymbolSuffix)
+
+	c.mu.Lock()
+	id, ok := c.marketIDs[asset]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "order_books"), "GET", c.baseURL+lighterOrderBooksPath, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var booksResp lighterOrderBooksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&booksResp); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range booksResp.OrderBooks {
+		c.marketIDs[b.Symbol] = b.MarketID
+	}
+	id, ok = c.marketIDs[asset]
+	if !ok {
+		return 0, fmt.Errorf("unknown symbol: %s", symbol)
+	}
+	return id, nil
 }
 
 type lighterOrderBookResponse struct {
@@ -41,15 +141,19 @@ type lighterOrder struct {
 	Price           string `json:"price"`
 }
 
-func (c *LighterClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData, error) {
-	// market_id=1 は BTC-PERP
-	url := lighterAPIURL + "?market_id=1&limit=1"
+func (c *LighterClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	marketID, err := c.marketID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s%s?market_id=%d&limit=1", c.baseURL, lighterOrderBookOrdersPath, marketID)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "order_book_orders"), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -79,14 +183,184 @@ func (c *LighterClient) FetchBTCPerpPrice(ctx context.Context) (*PriceData, erro
 		return nil, fmt.Errorf("failed to parse ask price: %w", err)
 	}
 
+	// orderBookOrders は時刻を返さないため ExchangeTs は設定しない
+	receivedAt := time.Now()
 	return &PriceData{
-		Bid: bid,
-		Ask: ask,
-		Ts:  time.Now(),
+		Bid:     bid,
+		Ask:     ask,
+		Ts:      receivedAt,
+		Latency: receivedAt.Sub(start),
 	}, nil
 }
 
-func (c *LighterClient) FetchBTCPerpFundingRate(ctx context.Context) (*FundingRateData, error) {
-	// TODO: Funding Rate 取得を実装
-	return nil, fmt.Errorf("not implemented")
+func (c *LighterClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	marketID, err := c.marketID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s%s?market_id=%d&limit=%d", c.baseURL, lighterOrderBookOrdersPath, marketID, depth)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "order_book_orders"), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var orderBookResp lighterOrderBookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&orderBookResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	bids, err := parseLighterOrders(orderBookResp.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseLighterOrders(orderBookResp.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// parseLighterOrders は個別注文の一覧を板の1段として変換する（同一価格の集約はしない）
+func parseLighterOrders(orders []lighterOrder) ([]OrderBookLevel, error) {
+	result := make([]OrderBookLevel, 0, len(orders))
+	for _, o := range orders {
+		px, err := strconv.ParseFloat(o.Price, 64)
+		if err != nil {
+			return nil, err
+		}
+		sz, err := strconv.ParseFloat(o.RemainingAmount, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+type lighterFundingRatesResponse struct {
+	Code         int                  `json:"code"`
+	FundingRates []lighterFundingRate `json:"funding_rates"`
+}
+
+type lighterFundingRate struct {
+	MarketID int     `json:"market_id"`
+	Exchange string  `json:"exchange"`
+	Symbol   string  `json:"symbol"`
+	Rate     float64 `json:"rate"`
+}
+
+func (c *LighterClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	marketID, err := c.marketID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "funding_rates"), "GET", c.baseURL+lighterFundingRatesPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var ratesResp lighterFundingRatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ratesResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// 他取引所・他銘柄のレートも含まれるため対象のマーケットのみを抽出
+	for _, fr := range ratesResp.FundingRates {
+		if fr.Exchange != "lighter" || fr.MarketID != marketID {
+			continue
+		}
+
+		// 8時間あたりのレートを1時間あたりに正規化
+		return &FundingRateData{
+			Rate: fr.Rate / lighterFundingIntervalHours,
+			Ts:   time.Now(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("invalid response: %s funding rate not found", symbol)
+}
+
+type lighterOrderBookDetailsResponse struct {
+	Code             int                       `json:"code"`
+	OrderBookDetails []lighterOrderBookDetails `json:"order_book_details"`
+}
+
+type lighterOrderBookDetails struct {
+	Symbol                string  `json:"symbol"`
+	MarketID              int     `json:"market_id"`
+	LastTradePrice        float64 `json:"last_trade_price"`
+	DailyQuoteTokenVolume float64 `json:"daily_quote_token_volume"`
+	OpenInterest          float64 `json:"open_interest"`
+}
+
+// FetchMarketStats は建玉と24時間の売買代金を返す。REST API はマーク価格・インデックス価格を返さない
+func (c *LighterClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	marketID, err := c.marketID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.baseURL + lighterOrderBookDetailsPath + "?market_id=" + strconv.Itoa(marketID)
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "order_book_details"), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var detailsResp lighterOrderBookDetailsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&detailsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, d := range detailsResp.OrderBookDetails {
+		if d.MarketID != marketID {
+			continue
+		}
+		openInterest := d.OpenInterest
+		volume := d.DailyQuoteTokenVolume
+		return &MarketStats{
+			OpenInterest: &openInterest,
+			Volume24h:    &volume,
+			Ts:           time.Now(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("invalid response: %s market details not found", symbol)
 }
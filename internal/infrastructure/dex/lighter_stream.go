@@ -0,0 +1,165 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const lighterWSURL = "wss://mainnet.zklighter.elliot.ai/stream"
+
+// NewLighterStreamingClient は symbols の order_book チャンネルを購読する StreamingClient を返す
+func NewLighterStreamingClient(rest *LighterClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+	return newStreamingClient(rest, newLighterStream(rest), symbols, staleAfter)
+}
+
+// lighterStream は購読直後のスナップショットと以降の差分からマーケットごとの板を組み立てる
+type lighterStream struct {
+	rest    *LighterClient
+	symbols map[int]string // market_id → シンボル
+	books   map[int]*lighterBook
+}
+
+type lighterBook struct {
+	bids map[float64]float64 // 価格 → 数量
+	asks map[float64]float64
+}
+
+func newLighterStream(rest *LighterClient) *lighterStream {
+	s := &lighterStream{
+		rest:    rest,
+		symbols: make(map[int]string),
+	}
+	s.reset()
+	return s
+}
+
+type lighterWSMessage struct {
+	Type      string             `json:"type"`
+	Channel   string             `json:"channel"`
+	OrderBook lighterWSOrderBook `json:"order_book"`
+}
+
+type lighterWSOrderBook struct {
+	Asks []lighterWSLevel `json:"asks"`
+	Bids []lighterWSLevel `json:"bids"`
+}
+
+type lighterWSLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+func (s *lighterStream) endpoint() string {
+	return s.rest.streamURL
+}
+
+func (s *lighterStream) subscriptions(ctx context.Context, symbols []string) ([]interface{}, error) {
+	subs := make([]interface{}, 0, len(symbols))
+	for _, symbol := range symbols {
+		marketID, err := s.rest.marketID(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		s.symbols[marketID] = symbol
+		subs = append(subs, map[string]string{
+			"type":    "subscribe",
+			"channel": fmt.Sprintf("order_book/%d", marketID),
+		})
+	}
+	return subs, nil
+}
+
+func (s *lighterStream) ping() interface{} {
+	return map[string]string{"type": "ping"}
+}
+
+func (s *lighterStream) reset() {
+	s.books = make(map[int]*lighterBook)
+}
+
+func (s *lighterStream) handle(msg []byte) (string, *PriceData, error) {
+	var wsMsg lighterWSMessage
+	if err := decodeStreamMessage(msg, &wsMsg); err != nil {
+		return "", nil, err
+	}
+
+	snapshot := false
+	switch {
+	case strings.HasPrefix(wsMsg.Type, "subscribed/order_book"):
+		snapshot = true
+	case strings.HasPrefix(wsMsg.Type, "update/order_book"):
+	default:
+		return "", nil, nil
+	}
+
+	// channel は "order_book:1" の形式
+	idx := strings.LastIndexAny(wsMsg.Channel, ":/")
+	marketID, err := strconv.Atoi(wsMsg.Channel[idx+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid channel: %q", wsMsg.Channel)
+	}
+	symbol, ok := s.symbols[marketID]
+	if !ok {
+		return "", nil, nil
+	}
+
+	book, ok := s.books[marketID]
+	if !ok || snapshot {
+		book = &lighterBook{
+			bids: make(map[float64]float64),
+			asks: make(map[float64]float64),
+		}
+		s.books[marketID] = book
+	}
+
+	if err := applyLighterLevels(book.bids, wsMsg.OrderBook.Bids); err != nil {
+		return "", nil, fmt.Errorf("failed to apply bids: %w", err)
+	}
+	if err := applyLighterLevels(book.asks, wsMsg.OrderBook.Asks); err != nil {
+		return "", nil, fmt.Errorf("failed to apply asks: %w", err)
+	}
+
+	var bid, ask float64
+	for px := range book.bids {
+		if px > bid {
+			bid = px
+		}
+	}
+	for px := range book.asks {
+		if ask == 0 || px < ask {
+			ask = px
+		}
+	}
+	if bid == 0 || ask == 0 {
+		return "", nil, nil
+	}
+
+	return symbol, &PriceData{
+		Bid: bid,
+		Ask: ask,
+		Ts:  time.Now(),
+	}, nil
+}
+
+// applyLighterLevels は差分を板に反映する。数量0の価格は削除する
+func applyLighterLevels(book map[float64]float64, levels []lighterWSLevel) error {
+	for _, l := range levels {
+		price, err := strconv.ParseFloat(l.Price, 64)
+		if err != nil {
+			return err
+		}
+		size, err := strconv.ParseFloat(l.Size, 64)
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			delete(book, price)
+			continue
+		}
+		book[price] = size
+	}
+	return nil
+}
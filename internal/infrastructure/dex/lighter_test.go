@@ -0,0 +1,49 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLighterFundingRateNormalization は funding-rates のレスポンスから Lighter のマーケットのレートだけを取り出し、
+// lighterFundingIntervalHours で1時間あたりに直すことを確かめる。固定データはドキュメントから書き起こしたもので、
+// レートの期間そのものは確かめない（TestLiveMarketData で本番と照合する）
+func TestLighterFundingRateNormalization(t *testing.T) {
+	recording := filepath.Join("testdata", "recordings", "lighter", "funding_rates_76f3629ed3c1.json")
+	raw, err := os.ReadFile(recording)
+	if err != nil {
+		t.Fatalf("failed to read recording: %v", err)
+	}
+	var rec struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		t.Fatalf("failed to decode recording: %v", err)
+	}
+
+	srv := newPayloadServer(t, map[string]string{lighterFundingRatesPath: rec.Body})
+	client := NewLighterClient(WithBaseURL(srv.URL), testTransport)
+
+	tests := []struct {
+		symbol string
+		want   float64
+	}{
+		{symbol: "ETH-PERP", want: 0.000096 / lighterFundingIntervalHours},
+		{symbol: "BTC-PERP", want: 0.000072 / lighterFundingIntervalHours},
+		{symbol: "SOL-PERP", want: 0.000064 / lighterFundingIntervalHours},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := client.FetchFundingRate(context.Background(), tt.symbol)
+			if err != nil {
+				t.Fatalf("FetchFundingRate: %v", err)
+			}
+			if !almostEqual(got.Rate, tt.want) {
+				t.Errorf("rate = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// liveAnchors は単位の確認の基準にする、記録と運用の実績がある取引所
+var liveAnchors = []string{"hyperliquid", "binance"}
+
+// liveExchanges は本番のレスポンスでまだ確認していない取引所
+var liveExchanges = []string{"dydx", "paradex", "drift", "bybit", "okx"}
+
+const (
+	// liveMaxPriceDeviation は取引所の仲値と基準の中央値の許容する乖離（単位の誤りは桁違いになる）
+	liveMaxPriceDeviation = 0.01
+	// liveMaxHourlyFunding は1時間あたりの Funding Rate の上限の目安
+	liveMaxHourlyFunding = 0.005
+	// liveMinComparableFunding は期間の比較に使う Binance の1時間あたりのレートの下限（0付近は比が定まらない）
+	liveMinComparableFunding = 0.000002
+)
+
+// TestLiveMarketData は本番 API に接続し、パース結果の単位が他の取引所と揃っていることを確かめる。
+// テストの固定データは本番から取得したものではないため、パーサーを変えたときやマージ前に
+// DEX_LIVE_TEST=1 go test ./internal/infrastructure/dex -run Live で実行する
+func TestLiveMarketData(t *testing.T) {
+	if os.Getenv("DEX_LIVE_TEST") == "" {
+		t.Skip("set DEX_LIVE_TEST=1 to call the production APIs")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clients := make(map[string]DexClient)
+	for _, exchange := range append(append([]string{}, liveAnchors...), liveExchanges...) {
+		client, err := NewClient(exchange, WithTransportConfig(TransportConfig{Timeout: 10 * time.Second}))
+		if err != nil {
+			t.Fatalf("failed to create %s client: %v", exchange, err)
+		}
+		clients[exchange] = client
+	}
+
+	var anchorMids []float64
+	for _, exchange := range liveAnchors {
+		client := clients[exchange]
+		price, err := client.FetchPrice(ctx, client.Symbol("BTC"))
+		if err != nil {
+			t.Fatalf("%s FetchPrice: %v", exchange, err)
+		}
+		anchorMids = append(anchorMids, (price.Bid+price.Ask)/2)
+	}
+	sort.Float64s(anchorMids)
+	reference := anchorMids[len(anchorMids)/2]
+
+	for _, exchange := range liveExchanges {
+		t.Run(exchange, func(t *testing.T) {
+			client := clients[exchange]
+			symbol := client.Symbol("BTC")
+
+			price, err := client.FetchPrice(ctx, symbol)
+			if err != nil {
+				t.Fatalf("FetchPrice(%s): %v", symbol, err)
+			}
+			if price.Bid <= 0 || price.Ask < price.Bid {
+				t.Errorf("bid/ask = %v/%v", price.Bid, price.Ask)
+			}
+			mid := (price.Bid + price.Ask) / 2
+			if math.Abs(mid-reference)/reference > liveMaxPriceDeviation {
+				t.Errorf("mid = %v, want within %v of %v (anchors %v)", mid, liveMaxPriceDeviation, reference, liveAnchors)
+			}
+
+			funding, err := client.FetchFundingRate(ctx, symbol)
+			if err != nil {
+				t.Fatalf("FetchFundingRate(%s): %v", symbol, err)
+			}
+			if math.Abs(funding.Rate) > liveMaxHourlyFunding {
+				t.Errorf("hourly funding = %v, want |rate| <= %v", funding.Rate, liveMaxHourlyFunding)
+			}
+			t.Logf("mid=%.2f (anchor %.2f) hourly funding=%.8f", mid, reference, funding.Rate)
+		})
+	}
+
+	// Lighter の funding-rates API は Binance の行も返すため、BinanceClient の1時間あたりのレートと比べて
+	// lighterFundingIntervalHours が API のレートの期間と合っているかを確かめる（期間を誤ると比が桁で変わる）
+	t.Run("lighter funding period", func(t *testing.T) {
+		binance := clients["binance"]
+		want, err := binance.FetchFundingRate(ctx, binance.Symbol("BTC"))
+		if err != nil {
+			t.Fatalf("binance FetchFundingRate: %v", err)
+		}
+		if math.Abs(want.Rate) < liveMinComparableFunding {
+			t.Skipf("binance hourly funding %v is too close to 0 to compare", want.Rate)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, lighterBaseURL+lighterFundingRatesPath, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("funding-rates: %v", err)
+		}
+		defer resp.Body.Close()
+		var payload lighterFundingRatesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode funding-rates: %v", err)
+		}
+
+		for _, fr := range payload.FundingRates {
+			if fr.Exchange != "binance" || fr.Symbol != "BTC" {
+				continue
+			}
+			got := fr.Rate / lighterFundingIntervalHours
+			if ratio := got / want.Rate; ratio < 0.5 || ratio > 2 {
+				t.Errorf("lighter's binance BTC rate / %d = %v, want close to binance hourly %v", lighterFundingIntervalHours, got, want.Rate)
+			}
+			t.Logf("lighter's binance BTC rate=%.8f binance hourly=%.8f", fr.Rate, want.Rate)
+			return
+		}
+		t.Fatal("funding-rates has no binance BTC row")
+	})
+}
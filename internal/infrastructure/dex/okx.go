@@ -0,0 +1,244 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	okxBaseURL         = "https://www.okx.com"
+	okxTickerPath      = "/api/v5/market/ticker"
+	okxBooksPath       = "/api/v5/market/books"
+	okxFundingRatePath = "/api/v5/public/funding-rate"
+	okxInstrumentsPath = "/api/v5/public/instruments"
+
+	// okxSymbolSuffix は OKX の USDT 建て無期限先物のシンボルの接尾辞（BTC-USDT-SWAP など）
+	okxSymbolSuffix = "-USDT-SWAP"
+
+	// okxFundingIntervalHours は OKX の主要銘柄の Funding 精算間隔（時間）
+	okxFundingIntervalHours = 8
+	// okxMaxDepth は books API が受け付ける sz の上限
+	okxMaxDepth = 400
+)
+
+// OKXClient は OKX v5 の USDT 建て無期限先物。板の数量は契約数のため契約サイズ（ctVal）を掛けて基軸通貨の数量にする
+type OKXClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	ctVals map[string]float64 // instId → 1契約あたりの基軸通貨の数量
+}
+
+func NewOKXClient(opts ...Option) *OKXClient {
+	o := newClientOptions("okx", okxBaseURL, "", opts)
+	return &OKXClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		httpClient: newHTTPClient(o),
+		ctVals:     make(map[string]float64),
+	}
+}
+
+func (c *OKXClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTC-USDT-SWAP 形式のシンボルを返す
+func (c *OKXClient) Symbol(asset string) string {
+	return asset + okxSymbolSuffix
+}
+
+// okxResponse は v5 API の共通の形式。code は文字列で "0" が成功
+type okxResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// get は v5 API を呼び出し、data を v にデコードする
+func (c *OKXClient) get(ctx context.Context, endpoint, path string, query url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), "GET", c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var okxResp okxResponse
+	if err := json.NewDecoder(resp.Body).Decode(&okxResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if okxResp.Code != "0" {
+		return fmt.Errorf("api error %s: %s", okxResp.Code, okxResp.Msg)
+	}
+	if err := json.Unmarshal(okxResp.Data, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+type okxTicker struct {
+	InstID string `json:"instId"`
+	BidPx  string `json:"bidPx"`
+	BidSz  string `json:"bidSz"`
+	AskPx  string `json:"askPx"`
+	AskSz  string `json:"askSz"`
+	Ts     string `json:"ts"`
+}
+
+func (c *OKXClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	start := time.Now()
+	var tickers []okxTicker
+	if err := c.get(ctx, "ticker", okxTickerPath, url.Values{"instId": {symbol}}, &tickers); err != nil {
+		return nil, err
+	}
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("invalid response: %s not found", symbol)
+	}
+	ticker := tickers[0]
+
+	bid, err := strconv.ParseFloat(ticker.BidPx, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(ticker.AskPx, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	var exchangeTs time.Time
+	if ms, err := strconv.ParseInt(ticker.Ts, 10, 64); err == nil {
+		exchangeTs = msToTime(ms)
+	}
+
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: exchangeTs,
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+type okxInstrument struct {
+	InstID string `json:"instId"`
+	CtVal  string `json:"ctVal"`
+}
+
+// ctVal は instId の契約サイズを返す。一度取得したものは使い回す
+func (c *OKXClient) ctVal(ctx context.Context, symbol string) (float64, error) {
+	c.mu.Lock()
+	v, ok := c.ctVals[symbol]
+	c.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	var instruments []okxInstrument
+	if err := c.get(ctx, "instruments", okxInstrumentsPath, url.Values{"instType": {"SWAP"}, "instId": {symbol}}, &instruments); err != nil {
+		return 0, err
+	}
+	if len(instruments) == 0 {
+		return 0, fmt.Errorf("unknown symbol: %s", symbol)
+	}
+	v, err := strconv.ParseFloat(instruments[0].CtVal, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse contract value: %w", err)
+	}
+
+	c.mu.Lock()
+	c.ctVals[symbol] = v
+	c.mu.Unlock()
+	return v, nil
+}
+
+type okxBook struct {
+	Asks [][]string `json:"asks"`
+	Bids [][]string `json:"bids"`
+	Ts   string     `json:"ts"`
+}
+
+func (c *OKXClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	ctVal, err := c.ctVal(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := min(max(depth, 1), okxMaxDepth)
+	var books []okxBook
+	if err := c.get(ctx, "books", okxBooksPath, url.Values{"instId": {symbol}, "sz": {strconv.Itoa(limit)}}, &books); err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("invalid response: %s order book not found", symbol)
+	}
+
+	// 各段は [価格, 契約数, 廃止された項目, 注文数]
+	bids, err := parseStringLevels(books[0].Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseStringLevels(books[0].Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	for i := range bids {
+		bids[i].Size *= ctVal
+	}
+	for i := range asks {
+		asks[i].Size *= ctVal
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+type okxFundingRate struct {
+	InstID      string `json:"instId"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime string `json:"fundingTime"`
+}
+
+func (c *OKXClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	var rates []okxFundingRate
+	if err := c.get(ctx, "funding_rate", okxFundingRatePath, url.Values{"instId": {symbol}}, &rates); err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("invalid response: %s funding rate not found", symbol)
+	}
+
+	rate, err := strconv.ParseFloat(rates[0].FundingRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	// 8時間ごとのレートを1時間あたりに正規化
+	return &FundingRateData{
+		Rate: rate / okxFundingIntervalHours,
+		Ts:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,235 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	paradexBaseURL            = "https://api.prod.paradex.trade/v1"
+	paradexBBOPath            = "/bbo/"
+	paradexOrderBookPath      = "/orderbook/"
+	paradexMarketsSummaryPath = "/markets/summary"
+
+	// paradexSymbolSuffix は Paradex の無期限先物のシンボルの接尾辞（BTC-USD-PERP など）
+	paradexSymbolSuffix = "-USD-PERP"
+
+	// paradexFundingPeriodHours は markets/summary の funding_rate の期間（時間）
+	paradexFundingPeriodHours = 8
+)
+
+type ParadexClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewParadexClient(opts ...Option) *ParadexClient {
+	o := newClientOptions("paradex", paradexBaseURL, "", opts)
+	return &ParadexClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		httpClient: newHTTPClient(o),
+	}
+}
+
+func (c *ParadexClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTC-USD-PERP 形式のシンボルを返す
+func (c *ParadexClient) Symbol(asset string) string {
+	return asset + paradexSymbolSuffix
+}
+
+type paradexBBOResponse struct {
+	Market        string `json:"market"`
+	Bid           string `json:"bid"`
+	BidSize       string `json:"bid_size"`
+	Ask           string `json:"ask"`
+	AskSize       string `json:"ask_size"`
+	LastUpdatedAt int64  `json:"last_updated_at"`
+	SeqNo         int64  `json:"seq_no"`
+}
+
+func (c *ParadexClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "bbo"), "GET", c.baseURL+paradexBBOPath+url.PathEscape(symbol), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var bboResp paradexBBOResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bboResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	bid, err := strconv.ParseFloat(bboResp.Bid, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := strconv.ParseFloat(bboResp.Ask, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:        bid,
+		Ask:        ask,
+		Ts:         receivedAt,
+		ExchangeTs: msToTime(bboResp.LastUpdatedAt),
+		Latency:    receivedAt.Sub(start),
+	}, nil
+}
+
+type paradexOrderBookResponse struct {
+	Market        string     `json:"market"`
+	Bids          [][]string `json:"bids"`
+	Asks          [][]string `json:"asks"`
+	LastUpdatedAt int64      `json:"last_updated_at"`
+	SeqNo         int64      `json:"seq_no"`
+}
+
+func (c *ParadexClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	u := fmt.Sprintf("%s%s%s?depth=%d", c.baseURL, paradexOrderBookPath, url.PathEscape(symbol), depth)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "orderbook"), "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var bookResp paradexOrderBookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bookResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	bids, err := parseStringLevels(bookResp.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseStringLevels(bookResp.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+type paradexMarketsSummaryResponse struct {
+	Results []paradexMarketSummary `json:"results"`
+}
+
+type paradexMarketSummary struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"mark_price"`
+	UnderlyingPrice string `json:"underlying_price"`
+	FundingRate     string `json:"funding_rate"`
+	OpenInterest    string `json:"open_interest"`
+	Volume24h       string `json:"volume_24h"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+func (c *ParadexClient) fetchMarketSummary(ctx context.Context, symbol string) (*paradexMarketSummary, error) {
+	u := c.baseURL + paradexMarketsSummaryPath + "?market=" + url.QueryEscape(symbol)
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "markets_summary"), "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var summaryResp paradexMarketsSummaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&summaryResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for i, s := range summaryResp.Results {
+		if s.Symbol == symbol {
+			return &summaryResp.Results[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid response: %s market summary not found", symbol)
+}
+
+func (c *ParadexClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	summary, err := c.fetchMarketSummary(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := strconv.ParseFloat(summary.FundingRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	// 8時間あたりのレートを1時間あたりに正規化
+	return &FundingRateData{
+		Rate: rate / paradexFundingPeriodHours,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// FetchMarketStats はマーク価格・原資産価格・建玉・24時間の売買代金を返す
+func (c *ParadexClient) FetchMarketStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	summary, err := c.fetchMarketSummary(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MarketStats{Ts: time.Now()}
+	if stats.MarkPrice, err = parseOptionalFloat(summary.MarkPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	if stats.IndexPrice, err = parseOptionalFloat(summary.UnderlyingPrice); err != nil {
+		return nil, fmt.Errorf("failed to parse underlying price: %w", err)
+	}
+	if stats.OpenInterest, err = parseOptionalFloat(summary.OpenInterest); err != nil {
+		return nil, fmt.Errorf("failed to parse open interest: %w", err)
+	}
+	if stats.Volume24h, err = parseOptionalFloat(summary.Volume24h); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return stats, nil
+}
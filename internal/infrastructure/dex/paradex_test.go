@@ -0,0 +1,85 @@
+package dex
+
+import (
+	"context"
+	"testing"
+)
+
+// paradexSummaryPayload は /markets/summary?market=BTC-USD-PERP の形式
+const paradexSummaryPayload = `{"results":[{"symbol":"BTC-USD-PERP","oracle_price":"106790.81","mark_price":"106801.2","last_traded_price":"106805.1","bid":"106804.9","ask":"106805.3","volume_24h":"231982341.112","total_volume":"98123412398.12","created_at":1760745601012,"underlying_price":"106789.93","open_interest":"1984.512","funding_rate":"0.00009162","price_change_rate_24h":"-0.0121","future_funding_rate":"0.0000871"}]}`
+
+func TestParadexFetchFundingRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		symbol  string
+		want    float64
+		wantErr bool
+	}{
+		{
+			// funding_rate は8時間あたりのレート
+			name:    "8h rate",
+			payload: paradexSummaryPayload,
+			symbol:  "BTC-USD-PERP",
+			want:    0.00009162 / 8,
+		},
+		{
+			name:    "negative rate",
+			payload: `{"results":[{"symbol":"ETH-USD-PERP","mark_price":"3889.41","underlying_price":"3888.97","funding_rate":"-0.0000412","open_interest":"23812.4","volume_24h":"98123411.2","created_at":1760745601012}]}`,
+			symbol:  "ETH-USD-PERP",
+			want:    -0.0000412 / 8,
+		},
+		{
+			name:    "market not in summary",
+			payload: paradexSummaryPayload,
+			symbol:  "ETH-USD-PERP",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPayloadServer(t, map[string]string{paradexMarketsSummaryPath: tt.payload})
+			client := NewParadexClient(WithBaseURL(srv.URL), testTransport)
+
+			got, err := client.FetchFundingRate(context.Background(), tt.symbol)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FetchFundingRate = %v, want error", got.Rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchFundingRate: %v", err)
+			}
+			if !almostEqual(got.Rate, tt.want) {
+				t.Errorf("rate = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
+
+func TestParadexFetchMarketStats(t *testing.T) {
+	srv := newPayloadServer(t, map[string]string{paradexMarketsSummaryPath: paradexSummaryPayload})
+	client := NewParadexClient(WithBaseURL(srv.URL), testTransport)
+
+	stats, err := client.FetchMarketStats(context.Background(), "BTC-USD-PERP")
+	if err != nil {
+		t.Fatalf("FetchMarketStats: %v", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"mark price", stats.MarkPrice, 106801.2},
+		{"index price", stats.IndexPrice, 106789.93},
+		{"open interest", stats.OpenInterest, 1984.512},
+		{"volume", stats.Volume24h, 231982341.112},
+	} {
+		if f.got == nil || *f.got != f.want {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+}
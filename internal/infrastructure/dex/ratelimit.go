@@ -0,0 +1,154 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitWarnRatio を下回る残量になったら警告を出す
+	rateLimitWarnRatio = 0.2
+	// rateLimitWarnInterval は警告ログの最短間隔
+	rateLimitWarnInterval = 10 * time.Second
+)
+
+// RateLimit は取引所ごとのリクエスト制限。RequestsPerSecond は1秒あたりに回復する重み、
+// Weights はエンドポイント名（l2_book などの snake_case）→ 1回で消費する重み（未指定は 1）
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+	Weights           map[string]int
+}
+
+// RateLimitStats はレート制限の集計値
+type RateLimitStats struct {
+	Requests        int64   `json:"requests"`
+	Throttled       int64   `json:"throttled"`         // 待たされたリクエスト数
+	ThrottledWaitMs float64 `json:"throttled_wait_ms"` // 待った時間の合計
+	Rejected        int64   `json:"rejected"`          // 期限までに枠が空かない・待機中に取り消されたため送らなかったリクエスト数
+	Available       float64 `json:"available"`         // 現在の残量
+	Burst           int     `json:"burst"`
+}
+
+// RateLimiter はトークンバケットで取引所への重み付きリクエストを制限する。
+// 同じ取引所のクライアントの全呼び出し（REST フォールバックを含む）で共有する
+type RateLimiter struct {
+	name  string
+	limit RateLimit
+
+	mu       sync.Mutex
+	tokens   float64
+	last     time.Time
+	lastWarn time.Time
+	stats    RateLimitStats
+}
+
+func NewRateLimiter(name string, limit RateLimit) *RateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &RateLimiter{
+		name:   name,
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait は endpoint の重み分の枠が空くまで待つ。ctx の期限までに空かない場合は待たずにエラーを返す。
+// 待機中に ctx が終了した場合は確保した枠を返す
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	weight := 1
+	if w, ok := l.limit.Weights[endpoint]; ok {
+		weight = w
+	}
+
+	wait, err := l.reserve(ctx, float64(weight))
+	if err != nil || wait == 0 {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel(float64(weight))
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancel は reserve で確保したがリクエストを送らなかった分の枠を返す
+func (l *RateLimiter) cancel(weight float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	l.tokens = min(l.tokens+weight, float64(l.limit.Burst))
+	l.stats.Rejected++
+}
+
+// reserve は重み分の枠を確保し、使えるようになるまでの待ち時間を返す
+func (l *RateLimiter) reserve(ctx context.Context, weight float64) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+	l.stats.Requests++
+
+	var wait time.Duration
+	if l.tokens < weight {
+		wait = time.Duration((weight - l.tokens) / l.limit.RequestsPerSecond * float64(time.Second))
+		if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+			l.stats.Rejected++
+			return 0, fmt.Errorf("rate limit: %s needs %v to free up", l.name, wait)
+		}
+		l.stats.Throttled++
+		l.stats.ThrottledWaitMs += float64(wait) / float64(time.Millisecond)
+	}
+	l.tokens -= weight
+
+	if l.tokens < float64(l.limit.Burst)*rateLimitWarnRatio && now.Sub(l.lastWarn) >= rateLimitWarnInterval {
+		l.lastWarn = now
+		log.Printf("[%s] approaching rate limit: %.1f/%d remaining (throttled %d so far)",
+			l.name, max(l.tokens, 0), l.limit.Burst, l.stats.Throttled)
+	}
+	return wait, nil
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.limit.RequestsPerSecond
+	if l.tokens > float64(l.limit.Burst) {
+		l.tokens = float64(l.limit.Burst)
+	}
+	l.last = now
+}
+
+// Stats は集計値を返す
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	stats := l.stats
+	stats.Available = max(l.tokens, 0)
+	stats.Burst = l.limit.Burst
+	return stats
+}
+
+type endpointKey struct{}
+
+// withEndpoint はレート制限の重みを決めるエンドポイント名を ctx に載せる
+func withEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+func endpointFrom(ctx context.Context) string {
+	endpoint, _ := ctx.Value(endpointKey{}).(string)
+	return endpoint
+}
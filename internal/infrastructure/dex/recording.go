@@ -0,0 +1,186 @@
+package dex
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNoRecording は再生するレスポンスが記録されていないことを表す
+var ErrNoRecording = errors.New("no recorded response")
+
+// Recording は1組のリクエストとレスポンス。URI はベース URL からの相対パスとクエリ
+type Recording struct {
+	Endpoint    string      `json:"endpoint"`
+	Method      string      `json:"method"`
+	URI         string      `json:"uri"`
+	RequestBody string      `json:"request_body,omitempty"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        string      `json:"body"`
+}
+
+// WithRecording は取引所への全リクエストとレスポンスを dir/<クライアント名>/ に記録する
+func WithRecording(dir string) Option {
+	return func(o *clientOptions) {
+		o.recordDir = dir
+	}
+}
+
+// WithReplay は実際には通信せず、WithRecording で記録したレスポンスを dir/<クライアント名>/ から返す
+func WithReplay(dir string) Option {
+	return func(o *clientOptions) {
+		o.replayDir = dir
+	}
+}
+
+// recordingKey は記録のファイル名。同じリクエストは同じファイルになる（後から記録したものが残る）
+func recordingKey(endpoint, method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	if endpoint == "" {
+		endpoint = "request"
+	}
+	return endpoint + "_" + hex.EncodeToString(h.Sum(nil))[:12] + ".json"
+}
+
+// relativeURI はリクエストの URL からベース URL を除いたパスとクエリを返す。
+// 一方のベース URL が他方を含む場合（モックなど）に備えて長いものから試す
+func relativeURI(req *http.Request, baseURLs []string) string {
+	uri := req.URL.String()
+	rel, matched := "", 0
+	for _, baseURL := range baseURLs {
+		if r, ok := strings.CutPrefix(uri, baseURL); ok && len(baseURL) > matched {
+			rel, matched = r, len(baseURL)
+		}
+	}
+	if matched == 0 {
+		return req.URL.RequestURI()
+	}
+	return rel
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// recordingTransport は base の通信をそのまま返しつつ、レスポンスをファイルに書き出す
+type recordingTransport struct {
+	base     http.RoundTripper
+	dir      string
+	baseURLs []string
+
+	mu sync.Mutex
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := Recording{
+		Endpoint:    endpointFrom(req.Context()),
+		Method:      req.Method,
+		URI:         relativeURI(req, t.baseURLs),
+		RequestBody: string(reqBody),
+		Status:      resp.StatusCode,
+		Header:      http.Header{"Content-Type": resp.Header.Values("Content-Type")},
+		Body:        string(body),
+	}
+	if err := t.save(rec); err != nil {
+		return nil, fmt.Errorf("failed to save recording: %w", err)
+	}
+	return resp, nil
+}
+
+func (t *recordingTransport) save(rec Recording) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(t.dir, recordingKey(rec.Endpoint, rec.Method, rec.URI, []byte(rec.RequestBody)))
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// replayTransport は記録したレスポンスを返す。同じリクエストには常に同じレスポンスを返す
+type replayTransport struct {
+	dir      string
+	baseURLs []string
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := relativeURI(req, t.baseURLs)
+	endpoint := endpointFrom(req.Context())
+
+	data, err := os.ReadFile(filepath.Join(t.dir, recordingKey(endpoint, req.Method, uri, reqBody)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, req.Method, uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("invalid recording for %s %s: %w", req.Method, uri, err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
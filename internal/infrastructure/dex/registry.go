@@ -0,0 +1,111 @@
+package dex
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// clientFactory は取引所の種類ごとのクライアントの生成方法
+type clientFactory struct {
+	// quoteAsset は既定のシンボルの価格の単位（exchanges[].quote_asset の省略時）
+	quoteAsset string
+	newClient  func(opts []Option) DexClient
+	// newStream は newClient で生成したクライアントを REST フォールバックに使う WebSocket クライアントを返す。
+	// nil なら REST のみ
+	newStream func(rest DexClient, symbols []string, staleAfter time.Duration) *StreamingClient
+}
+
+// factories は種類（config.yaml の exchanges[].type）→ 生成方法
+var factories = map[string]clientFactory{
+	"hyperliquid": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewHyperliquidClient(opts...) },
+		newStream: func(rest DexClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+			return NewHyperliquidStreamingClient(rest.(*HyperliquidClient), symbols, staleAfter)
+		},
+	},
+	"lighter": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewLighterClient(opts...) },
+		newStream: func(rest DexClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+			return NewLighterStreamingClient(rest.(*LighterClient), symbols, staleAfter)
+		},
+	},
+	"aster": {
+		quoteAsset: "USDT",
+		newClient:  func(opts []Option) DexClient { return NewAsterClient(opts...) },
+		newStream: func(rest DexClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+			return NewAsterStreamingClient(rest.(*AsterClient), symbols, staleAfter)
+		},
+	},
+	"dydx": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewDydxClient(opts...) },
+	},
+	"paradex": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewParadexClient(opts...) },
+	},
+	"vertex": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewVertexClient(opts...) },
+	},
+	"drift": {
+		quoteAsset: "USD",
+		newClient:  func(opts []Option) DexClient { return NewDriftClient(opts...) },
+	},
+	// 以下は CEX。公正価格の参照用（exchanges[].reference）
+	"binance": {
+		quoteAsset: "USDT",
+		newClient:  func(opts []Option) DexClient { return NewBinanceClient(opts...) },
+		newStream: func(rest DexClient, symbols []string, staleAfter time.Duration) *StreamingClient {
+			return NewAsterStreamingClient(rest.(*AsterClient), symbols, staleAfter)
+		},
+	},
+	"bybit": {
+		quoteAsset: "USDT",
+		newClient:  func(opts []Option) DexClient { return NewBybitClient(opts...) },
+	},
+	"okx": {
+		quoteAsset: "USDT",
+		newClient:  func(opts []Option) DexClient { return NewOKXClient(opts...) },
+	},
+}
+
+// NewClient は種類に対応する DexClient を生成する
+func NewClient(typ string, opts ...Option) (DexClient, error) {
+	f, ok := factories[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown exchange type %q (available: %v)", typ, Types())
+	}
+	return f.newClient(opts), nil
+}
+
+// NewStreamingClient は NewClient(typ) で生成した rest を REST フォールバックに使う WebSocket クライアントを返す。
+// WebSocket に対応していない種類は nil
+func NewStreamingClient(typ string, rest DexClient, symbols []string, staleAfter time.Duration) (*StreamingClient, error) {
+	f, ok := factories[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown exchange type %q (available: %v)", typ, Types())
+	}
+	if f.newStream == nil {
+		return nil, nil
+	}
+	return f.newStream(rest, symbols, staleAfter), nil
+}
+
+// QuoteAsset は種類の既定のシンボルの価格の単位（USD / USDT）を返す。未登録の種類は空文字列
+func QuoteAsset(typ string) string {
+	return factories[typ].quoteAsset
+}
+
+// Types は登録されている種類を返す
+func Types() []string {
+	types := make([]string, 0, len(factories))
+	for typ := range factories {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,113 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ReplayCase は記録・再生する1回の呼び出し（testdata/cases.json の1要素）
+type ReplayCase struct {
+	Exchange string `json:"exchange"` // クライアントの種類
+	Call     string `json:"call"`     // price / funding / order_book / market_stats
+	Symbol   string `json:"symbol"`
+	Depth    int    `json:"depth,omitempty"`
+}
+
+// Name はゴールデンファイルの名前（拡張子なし）
+func (c ReplayCase) Name() string {
+	return fmt.Sprintf("%s_%s_%s", c.Exchange, c.Call, c.Symbol)
+}
+
+// LoadReplayCases は cases.json を読み込む
+func LoadReplayCases(path string) ([]ReplayCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cases []ReplayCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+// 時刻やレイテンシなど実行ごとに変わる値を除いたパース結果
+type replayPriceResult struct {
+	Bid        float64    `json:"bid"`
+	Ask        float64    `json:"ask"`
+	ExchangeTs *time.Time `json:"exchange_ts"`
+}
+
+type replayFundingResult struct {
+	Rate float64 `json:"rate"`
+}
+
+type replayOrderBookResult struct {
+	Bids [][2]float64 `json:"bids"`
+	Asks [][2]float64 `json:"asks"`
+}
+
+type replayMarketStatsResult struct {
+	MarkPrice    *float64 `json:"mark_price"`
+	IndexPrice   *float64 `json:"index_price"`
+	OpenInterest *float64 `json:"open_interest"`
+	Volume24h    *float64 `json:"volume_24h"`
+}
+
+// RunReplayCase は呼び出しを実行し、パース結果をゴールデンファイルと同じ形式の JSON で返す
+func RunReplayCase(ctx context.Context, client DexClient, tc ReplayCase) ([]byte, error) {
+	var result interface{}
+	switch tc.Call {
+	case "price":
+		p, err := client.FetchPrice(ctx, tc.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		r := replayPriceResult{Bid: p.Bid, Ask: p.Ask}
+		if !p.ExchangeTs.IsZero() {
+			ts := p.ExchangeTs.UTC()
+			r.ExchangeTs = &ts
+		}
+		result = r
+	case "funding":
+		f, err := client.FetchFundingRate(ctx, tc.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		result = replayFundingResult{Rate: f.Rate}
+	case "order_book":
+		book, err := client.FetchOrderBook(ctx, tc.Symbol, tc.Depth)
+		if err != nil {
+			return nil, err
+		}
+		r := replayOrderBookResult{Bids: [][2]float64{}, Asks: [][2]float64{}}
+		for _, l := range book.Bids {
+			r.Bids = append(r.Bids, [2]float64{l.Price, l.Size})
+		}
+		for _, l := range book.Asks {
+			r.Asks = append(r.Asks, [2]float64{l.Price, l.Size})
+		}
+		result = r
+	case "market_stats":
+		sc, ok := client.(MarketStatsClient)
+		if !ok {
+			return nil, fmt.Errorf("%s does not support market stats", tc.Exchange)
+		}
+		s, err := sc.FetchMarketStats(ctx, tc.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		result = replayMarketStatsResult{MarkPrice: s.MarkPrice, IndexPrice: s.IndexPrice, OpenInterest: s.OpenInterest, Volume24h: s.Volume24h}
+	default:
+		return nil, fmt.Errorf("unknown call %q", tc.Call)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,57 @@
+package dex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestReplayGolden は testdata/recordings の記録を再生し、パース結果を testdata/golden と比較する。
+// ゴールデンファイルの更新は go run ./cmd/dexreplay -update。
+// 本番 API からまだ記録していない（ゴールデンファイルのない）ケースはスキップする
+func TestReplayGolden(t *testing.T) {
+	cases, err := LoadReplayCases(filepath.Join("testdata", "cases.json"))
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+
+	clients := make(map[string]DexClient)
+	for _, tc := range cases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			goldenPath := filepath.Join("testdata", "golden", tc.Name()+".json")
+			want, err := os.ReadFile(goldenPath)
+			if errors.Is(err, os.ErrNotExist) {
+				t.Skipf("not recorded yet; run go run ./cmd/dexreplay -record -missing -exchange %s against the production API", tc.Exchange)
+			}
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			client, ok := clients[tc.Exchange]
+			if !ok {
+				client, err = NewClient(tc.Exchange,
+					WithTransportConfig(TransportConfig{Timeout: 10 * time.Second}),
+					WithReplay(filepath.Join("testdata", "recordings")))
+				if err != nil {
+					t.Fatalf("failed to create client: %v", err)
+				}
+				clients[tc.Exchange] = client
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			got, err := RunReplayCase(ctx, client, tc)
+			if err != nil {
+				t.Fatalf("replay failed: %v", err)
+			}
+
+			if !bytes.Equal(want, got) {
+				t.Errorf("result differs from %s\n--- want\n%s--- got\n%s", goldenPath, want, got)
+			}
+		})
+	}
+}
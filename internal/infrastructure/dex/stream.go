@@ -0,0 +1,194 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// streamHeartbeatTimeout の間メッセージを受信しなければ接続断とみなす
+	streamHeartbeatTimeout = 30 * time.Second
+	// streamPingInterval はアプリケーションレベルの ping の送信間隔
+	streamPingInterval = 15 * time.Second
+	// streamMinBackoff / streamMaxBackoff は再接続待ちの範囲
+	streamMinBackoff = 1 * time.Second
+	streamMaxBackoff = 30 * time.Second
+	// streamOrigin は WebSocket ハンドシェイクの Origin ヘッダ
+	streamOrigin = "http://localhost/"
+)
+
+// streamProtocol は取引所ごとの WebSocket の購読・解析方法
+type streamProtocol interface {
+	endpoint() string
+	subscriptions(ctx context.Context, symbols []string) ([]interface{}, error)
+	// ping はアプリケーションレベルの ping メッセージを返す。不要なら nil
+	ping() interface{}
+	// reset は再接続時に呼ばれ、差分更新用の状態を破棄する
+	reset()
+	// handle はメッセージを解析し、シンボルと気配値を返す。価格更新でなければ data は nil
+	handle(msg []byte) (symbol string, data *PriceData, err error)
+}
+
+// StreamingClient は WebSocket で受信した最新の気配値を返す DexClient。
+// 接続が切れている間や気配値が古い場合、購読していないシンボルは REST の DexClient にフォールバックする
+type StreamingClient struct {
+	DexClient
+	protocol   streamProtocol
+	symbols    []string
+	staleAfter time.Duration
+
+	mu     sync.RWMutex
+	latest map[string]*PriceData // シンボル → 最新の気配値
+}
+
+func newStreamingClient(rest DexClient, protocol streamProtocol, symbols []string, staleAfter time.Duration) *StreamingClient {
+	return &StreamingClient{
+		DexClient:  rest,
+		protocol:   protocol,
+		symbols:    symbols,
+		staleAfter: staleAfter,
+		latest:     make(map[string]*PriceData),
+	}
+}
+
+func (c *StreamingClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	c.mu.RLock()
+	latest := c.latest[symbol]
+	c.mu.RUnlock()
+
+	if latest != nil && time.Since(latest.Ts) < c.staleAfter {
+		data := *latest
+		data.Cached = true
+		return &data, nil
+	}
+	return c.DexClient.FetchPrice(ctx, symbol)
+}
+
+// Start は ctx が終了するまで接続・購読・再接続を繰り返す
+func (c *StreamingClient) Start(ctx context.Context) {
+	backoff := streamMinBackoff
+
+	for {
+		startedAt := time.Now()
+		err := c.runOnce(ctx)
+		c.clearLatest()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// 一定時間つながっていた場合は待ち時間をリセット
+		if time.Since(startedAt) > streamMaxBackoff {
+			backoff = streamMinBackoff
+		}
+		log.Printf("[%s] stream disconnected, falling back to REST: %v (reconnecting in %v)", c.Name(), err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, streamMaxBackoff)
+	}
+}
+
+func (c *StreamingClient) runOnce(ctx context.Context) error {
+	config, err := websocket.NewConfig(c.protocol.endpoint(), streamOrigin)
+	if err != nil {
+		return fmt.Errorf("failed to create config: %w", err)
+	}
+
+	ws, err := config.DialContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to dial: %w", err)
+	}
+	defer ws.Close()
+
+	// ctx 終了時に受信待ちを解除する
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+
+	c.protocol.reset()
+	subs, err := c.protocol.subscriptions(ctx, c.symbols)
+	if err != nil {
+		return fmt.Errorf("failed to build subscriptions: %w", err)
+	}
+	for _, sub := range subs {
+		if err := websocket.JSON.Send(ws, sub); err != nil {
+			return fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+	log.Printf("[%s] stream connected", c.Name())
+
+	if ping := c.protocol.ping(); ping != nil {
+		go c.pingLoop(ws, ping, done)
+	}
+
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(streamHeartbeatTimeout)); err != nil {
+			return fmt.Errorf("failed to set deadline: %w", err)
+		}
+
+		var msg []byte
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return fmt.Errorf("failed to receive: %w", err)
+		}
+
+		symbol, data, err := c.protocol.handle(msg)
+		if err != nil {
+			return fmt.Errorf("failed to handle message: %w", err)
+		}
+		if data != nil {
+			c.setLatest(symbol, data)
+		}
+	}
+}
+
+func (c *StreamingClient) pingLoop(ws *websocket.Conn, ping interface{}, done <-chan struct{}) {
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := websocket.JSON.Send(ws, ping); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (c *StreamingClient) setLatest(symbol string, data *PriceData) {
+	c.mu.Lock()
+	c.latest[symbol] = data
+	c.mu.Unlock()
+}
+
+func (c *StreamingClient) clearLatest() {
+	c.mu.Lock()
+	c.latest = make(map[string]*PriceData)
+	c.mu.Unlock()
+}
+
+// decodeStreamMessage は JSON メッセージを v にデコードする
+func decodeStreamMessage(msg []byte, v interface{}) error {
+	if err := json.Unmarshal(msg, v); err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package dex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/infrastructure/httpretry"
+)
+
+// ErrCircuitOpen はサーキットブレーカーが開いていてリクエストを送らなかったことを表す
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// TransportConfig は DEX への HTTP リクエストの再試行とサーキットブレーカーの設定
+type TransportConfig struct {
+	Timeout         time.Duration // 1回の通信あたりのタイムアウト（再試行ごとに適用。全体の期限は呼び出し側の context）
+	MaxRetries      int           // 再試行の最大回数（0 なら再試行しない）
+	InitialBackoff  time.Duration
+	MaxBackoff      time.Duration
+	BreakerFailures int           // 連続してこの回数失敗したらブレーカーを開く（0 なら無効）
+	BreakerCooldown time.Duration // ブレーカーを開いてから試行を1件だけ許可するまでの時間
+}
+
+// DefaultTransportConfig は TransportConfig を指定しなかった場合の設定
+var DefaultTransportConfig = TransportConfig{
+	Timeout:         10 * time.Second,
+	MaxRetries:      2,
+	InitialBackoff:  200 * time.Millisecond,
+	MaxBackoff:      2 * time.Second,
+	BreakerFailures: 5,
+	BreakerCooldown: 30 * time.Second,
+}
+
+// Option はクライアント生成時の設定
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	name      string
+	baseURL   string
+	streamURL string
+	dataURL   string
+	transport TransportConfig
+	limiter   *RateLimiter
+	recordDir string
+	replayDir string
+}
+
+// WithName はクライアントの名前（= 取引所キー）を差し替える。同じ種類の取引所を複数登録する場合に使う
+func WithName(name string) Option {
+	return func(o *clientOptions) {
+		if name != "" {
+			o.name = name
+		}
+	}
+}
+
+// WithBaseURL は REST API のベース URL（https://api.hyperliquid.xyz など、パスは含めない）を差し替える
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		if baseURL != "" {
+			o.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// WithStreamURL は WebSocket の接続先（wss://api.hyperliquid.xyz/ws など）を差し替える
+func WithStreamURL(streamURL string) Option {
+	return func(o *clientOptions) {
+		if streamURL != "" {
+			o.streamURL = streamURL
+		}
+	}
+}
+
+// WithDataURL は板と別のホストにある REST API（Vertex の archive、Drift の data API）のベース URL を差し替える
+func WithDataURL(dataURL string) Option {
+	return func(o *clientOptions) {
+		if dataURL != "" {
+			o.dataURL = strings.TrimSuffix(dataURL, "/")
+		}
+	}
+}
+
+// WithTransportConfig は再試行とサーキットブレーカーの設定を差し替える
+func WithTransportConfig(cfg TransportConfig) Option {
+	return func(o *clientOptions) {
+		o.transport = cfg
+	}
+}
+
+// WithRateLimiter は取引所へのリクエストを limiter で制限する
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// newClientOptions は name / baseURL / streamURL を既定値として opts を適用する
+func newClientOptions(name, baseURL, streamURL string, opts []Option) clientOptions {
+	o := clientOptions{name: name, baseURL: baseURL, streamURL: streamURL, transport: DefaultTransportConfig}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// baseURLs は REST API のベース URL の一覧（記録のキーはここからの相対パス）
+func (o clientOptions) baseURLs() []string {
+	if o.dataURL == "" {
+		return []string{o.baseURL}
+	}
+	return []string{o.baseURL, o.dataURL}
+}
+
+// newHTTPClient は再試行・サーキットブレーカー・レート制限を備えた http.Client を返す。
+// ブレーカーはクライアント（= 取引所）ごとに持つ。記録・再生は再試行の内側で1回の通信ごとに行う。
+// Timeout は再試行と待ち時間を含めず1回の通信ごとに resilientTransport で適用するため、Client.Timeout は設定しない
+func newHTTPClient(o clientOptions) *http.Client {
+	base := http.DefaultTransport
+	switch {
+	case o.replayDir != "":
+		base = &replayTransport{dir: filepath.Join(o.replayDir, o.name), baseURLs: o.baseURLs()}
+	case o.recordDir != "":
+		base = &recordingTransport{base: base, dir: filepath.Join(o.recordDir, o.name), baseURLs: o.baseURLs()}
+	}
+
+	return &http.Client{
+		Transport: &resilientTransport{
+			base:    base,
+			cfg:     o.transport,
+			breaker: &circuitBreaker{threshold: o.transport.BreakerFailures, cooldown: o.transport.BreakerCooldown},
+			limiter: o.limiter,
+		},
+	}
+}
+
+// resilientTransport は 429 / 5xx / 通信エラーをジッター付き指数バックオフで再試行する。
+// Retry-After があればその時間待つが、context の期限を超える場合は待たずに諦める
+type resilientTransport struct {
+	base    http.RoundTripper
+	cfg     TransportConfig
+	breaker *circuitBreaker
+	limiter *RateLimiter // nil なら制限なし
+}
+
+func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	// 本文を作り直せないリクエストは再試行しない
+	maxRetries := t.cfg.MaxRetries
+	if req.Body != nil && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	backoff := t.cfg.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				t.finish(req, false)
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		// 再試行も取引所の制限を消費するので毎回枠を確保する
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context(), endpointFrom(req.Context())); err != nil {
+				t.breaker.release()
+				return nil, err
+			}
+		}
+
+		resp, err := t.roundTripOnce(req)
+		// 1回の通信のタイムアウトは、呼び出し側の期限が残っていれば再試行する
+		timedOut := errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil
+		if !timedOut && !isRetryable(resp, err) {
+			t.finish(req, err == nil && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests)
+			return resp, err
+		}
+		if attempt >= maxRetries || req.Context().Err() != nil {
+			t.finish(req, false)
+			return resp, err
+		}
+
+		wait := httpretry.Jitter(backoff)
+		if resp != nil {
+			if d, ok := httpretry.RetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = d
+			}
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			t.finish(req, false)
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			t.finish(req, false)
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, t.cfg.MaxBackoff)
+	}
+}
+
+// finish は結果をブレーカーに記録する。呼び出し側のキャンセル・期限切れで失敗した場合は
+// 取引所の障害ではないため失敗に数えず、半開状態の試行枠だけ返す
+func (t *resilientTransport) finish(req *http.Request, success bool) {
+	if !success && req.Context().Err() != nil {
+		t.breaker.release()
+		return
+	}
+	t.breaker.record(success)
+}
+
+// roundTripOnce は cfg.Timeout を期限として1回だけ通信する。期限はレスポンスの本文を閉じるまで有効
+func (t *resilientTransport) roundTripOnce(req *http.Request) (*http.Response, error) {
+	if t.cfg.Timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.cfg.Timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose は本文を閉じたときに通信の context を解放する
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isRetryable は 429 / 5xx / 通信エラーを再試行対象とする。呼び出し側のキャンセルと再生時の記録漏れは対象外
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrNoRecording)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// circuitBreaker は連続失敗で開き、cooldown 経過後に1件だけ試行を通す（半開）。
+// 試行が成功すれば閉じ、失敗すれば再び cooldown の間開く
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// release は結果を記録せずに半開状態の試行枠を返す（リクエストを送らなかった場合）
+func (b *circuitBreaker) release() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
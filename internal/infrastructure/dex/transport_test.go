@@ -0,0 +1,127 @@
+package dex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestResilientTransportTimeoutPerAttempt は Timeout が再試行ごとに適用され、
+// 1回目の通信のタイムアウト後も再試行で成功することを確かめる
+func TestResilientTransportTimeoutPerAttempt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient(clientOptions{name: "test", baseURL: srv.URL, transport: TransportConfig{
+		Timeout:        100 * time.Millisecond,
+		MaxRetries:     1,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}})
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(body) != "ok" || calls.Load() != 2 {
+		t.Errorf("body = %q after %d calls, want \"ok\" after 2", body, calls.Load())
+	}
+}
+
+// TestResilientTransportCallerCancelKeepsBreakerClosed は呼び出し側のキャンセル・期限切れを
+// 取引所の失敗に数えず、ブレーカーを開かないことを確かめる
+func TestResilientTransportCallerCancelKeepsBreakerClosed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block") != "" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient(clientOptions{name: "test", baseURL: srv.URL, transport: TransportConfig{
+		MaxRetries:      1,
+		InitialBackoff:  10 * time.Millisecond,
+		MaxBackoff:      10 * time.Millisecond,
+		BreakerFailures: 1,
+		BreakerCooldown: time.Hour,
+	}})
+
+	for _, name := range []string{"canceled", "deadline"} {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if name == "canceled" {
+			ctx, cancel = context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+		} else {
+			ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?block=1", nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if _, err := client.Do(req); err == nil {
+			t.Fatalf("%s: Do succeeded, want error", name)
+		}
+		cancel()
+	}
+
+	resp, err := client.Get(srv.URL)
+	if errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("breaker opened after caller cancellations")
+	}
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// TestRateLimiterCancelReturnsTokens は待機中に取り消したリクエストの枠が返されることを確かめる
+func TestRateLimiterCancelReturnsTokens(t *testing.T) {
+	l := NewRateLimiter("test", RateLimit{RequestsPerSecond: 1, Burst: 2})
+	if err := l.Wait(context.Background(), ""); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if err := l.Wait(context.Background(), ""); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	// 残量0で1枠を待っている間に取り消す
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if err := l.Wait(ctx, ""); err == nil {
+		t.Fatal("Wait succeeded, want context canceled")
+	}
+
+	// 返さなければ次の枠まで1秒分の負債（約 -1）が残る
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.5 {
+		t.Errorf("tokens = %v, want the canceled token returned (about 0)", tokens)
+	}
+	if stats := l.Stats(); stats.Rejected != 1 {
+		t.Errorf("rejected = %d, want 1", stats.Rejected)
+	}
+}
@@ -0,0 +1,306 @@
+package dex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	vertexBaseURL    = "https://gateway.prod.vertexprotocol.com/v1"
+	vertexArchiveURL = "https://archive.prod.vertexprotocol.com/v1"
+	vertexQueryPath  = "/query"
+
+	// vertexSymbolSuffix は Vertex の無期限先物のシンボルの接尾辞（BTC-PERP など）
+	vertexSymbolSuffix = "-PERP"
+
+	// vertexFundingPeriodHours は archive の funding_rate_x18 の期間（時間）
+	vertexFundingPeriodHours = 24
+)
+
+// vertexKnownProductIDs は主要銘柄の product_id。これ以外は symbols クエリで引く
+var vertexKnownProductIDs = map[string]int{
+	"BTC": 2,
+	"ETH": 4,
+}
+
+// VertexClient は Vertex の gateway（板）と archive（Funding）の API。価格・数量は 1e18 倍の整数の文字列
+type VertexClient struct {
+	name       string
+	baseURL    string
+	archiveURL string
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	productIDs map[string]int // 銘柄 → product_id
+}
+
+func NewVertexClient(opts ...Option) *VertexClient {
+	o := newClientOptions("vertex", vertexBaseURL, "", append([]Option{WithDataURL(vertexArchiveURL)}, opts...))
+	productIDs := make(map[string]int, len(vertexKnownProductIDs))
+	for asset, id := range vertexKnownProductIDs {
+		productIDs[asset] = id
+	}
+	return &VertexClient{
+		name:       o.name,
+		baseURL:    o.baseURL,
+		archiveURL: o.dataURL,
+		httpClient: newHTTPClient(o),
+		productIDs: productIDs,
+	}
+}
+
+func (c *VertexClient) Name() string {
+	return c.name
+}
+
+// Symbol は BTC-PERP 形式のシンボルを返す
+func (c *VertexClient) Symbol(asset string) string {
+	return asset + vertexSymbolSuffix
+}
+
+// vertexQueryResponse は gateway の query の共通の形式
+type vertexQueryResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+	Error  string          `json:"error"`
+}
+
+// query は gateway の query を実行し、data を v にデコードする
+func (c *VertexClient) query(ctx context.Context, endpoint, params string, v interface{}) error {
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), "GET", c.baseURL+vertexQueryPath+"?"+params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var queryResp vertexQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&queryResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if queryResp.Status != "success" {
+		return fmt.Errorf("query failed: %s", queryResp.Error)
+	}
+	if err := json.Unmarshal(queryResp.Data, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+type vertexSymbolsData struct {
+	Symbols map[string]vertexSymbol `json:"symbols"`
+}
+
+type vertexSymbol struct {
+	Type      string `json:"type"`
+	ProductID int    `json:"product_id"`
+	Symbol    string `json:"symbol"`
+}
+
+// productID はシンボルを product_id に変換する。数値のシンボルはそのまま product_id とみなす
+func (c *VertexClient) productID(ctx context.Context, symbol string) (int, error) {
+	if id, err := strconv.Atoi(symbol); err == nil {
+		return id, nil
+	}
+	asset := strings.TrimSuffix(symbol, vertexSymbolSuffix)
+
+	c.mu.Lock()
+	id, ok := c.productIDs[asset]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var data vertexSymbolsData
+	if err := c.query(ctx, "symbols", "type=symbols&product_type=perp", &data); err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range data.Symbols {
+		if s.Type != "perp" {
+			continue
+		}
+		c.productIDs[strings.TrimSuffix(s.Symbol, vertexSymbolSuffix)] = s.ProductID
+	}
+	id, ok = c.productIDs[asset]
+	if !ok {
+		return 0, fmt.Errorf("unknown symbol: %s", symbol)
+	}
+	return id, nil
+}
+
+type vertexMarketPriceData struct {
+	ProductID int    `json:"product_id"`
+	BidX18    string `json:"bid_x18"`
+	AskX18    string `json:"ask_x18"`
+}
+
+func (c *VertexClient) FetchPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	productID, err := c.productID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var data vertexMarketPriceData
+	if err := c.query(ctx, "market_price", fmt.Sprintf("type=market_price&product_id=%d", productID), &data); err != nil {
+		return nil, err
+	}
+
+	bid, err := parseX18(data.BidX18)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+
+	ask, err := parseX18(data.AskX18)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+
+	// market_price は時刻を返さないため ExchangeTs は設定しない
+	receivedAt := time.Now()
+	return &PriceData{
+		Bid:     bid,
+		Ask:     ask,
+		Ts:      receivedAt,
+		Latency: receivedAt.Sub(start),
+	}, nil
+}
+
+type vertexMarketLiquidityData struct {
+	Bids      [][]string `json:"bids"`
+	Asks      [][]string `json:"asks"`
+	Timestamp string     `json:"timestamp"`
+}
+
+func (c *VertexClient) FetchOrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
+	productID, err := c.productID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data vertexMarketLiquidityData
+	if err := c.query(ctx, "market_liquidity", fmt.Sprintf("type=market_liquidity&product_id=%d&depth=%d", productID, depth), &data); err != nil {
+		return nil, err
+	}
+
+	bids, err := parseX18Levels(data.Bids, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	asks, err := parseX18Levels(data.Asks, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{
+		Bids: bids,
+		Asks: asks,
+		Ts:   time.Now(),
+	}, nil
+}
+
+// parseX18Levels は ["価格x18", "数量x18"] 形式の配列を変換する
+func parseX18Levels(levels [][]string, depth int) ([]OrderBookLevel, error) {
+	if len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("invalid level: %v", l)
+		}
+		px, err := parseX18(l[0])
+		if err != nil {
+			return nil, err
+		}
+		sz, err := parseX18(l[1])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, OrderBookLevel{Price: px, Size: sz})
+	}
+	return result, nil
+}
+
+// parseX18 は 1e18 倍の整数の文字列を小数に変換する
+func parseX18(s string) (float64, error) {
+	return parseFixedPoint(s, 18)
+}
+
+type vertexFundingRateRequest struct {
+	FundingRate struct {
+		ProductID int `json:"product_id"`
+	} `json:"funding_rate"`
+}
+
+type vertexFundingRateResponse struct {
+	ProductID      int    `json:"product_id"`
+	FundingRateX18 string `json:"funding_rate_x18"`
+	UpdateTime     string `json:"update_time"`
+}
+
+func (c *VertexClient) FetchFundingRate(ctx context.Context, symbol string) (*FundingRateData, error) {
+	productID, err := c.productID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody vertexFundingRateRequest
+	reqBody.FundingRate.ProductID = productID
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, "funding_rate"), "POST", c.archiveURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var rateResp vertexFundingRateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rateResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	rate, err := parseX18(rateResp.FundingRateX18)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	// 24時間あたりのレートを1時間あたりに正規化
+	return &FundingRateData{
+		Rate: rate / vertexFundingPeriodHours,
+		Ts:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,104 @@
+package dex
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseX18(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    float64
+		wantErr bool
+	}{
+		{name: "price", s: "106801000000000000000000", want: 106801},
+		{name: "fractional price", s: "3889450000000000000000", want: 3889.45},
+		{name: "size", s: "1000000000000000", want: 0.001},
+		{name: "funding rate", s: "2447900598160952", want: 0.002447900598160952},
+		{name: "negative funding rate", s: "-149137018059882", want: -0.000149137018059882},
+		{name: "zero", s: "0", want: 0},
+		{name: "empty", s: "", wantErr: true},
+		{name: "decimal string", s: "106801.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseX18(tt.s)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseX18(%q) = %v, want error", tt.s, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseX18(%q): %v", tt.s, err)
+			}
+			if !almostEqual(got, tt.want) {
+				t.Errorf("parseX18(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVertexFetchPrice(t *testing.T) {
+	srv := newPayloadServer(t, map[string]string{
+		vertexQueryPath: `{"status":"success","data":{"product_id":2,"bid_x18":"106801000000000000000000","ask_x18":"106802500000000000000000"},"request_type":"query_market_price"}`,
+	})
+	client := NewVertexClient(WithBaseURL(srv.URL), testTransport)
+
+	got, err := client.FetchPrice(context.Background(), "BTC-PERP")
+	if err != nil {
+		t.Fatalf("FetchPrice: %v", err)
+	}
+	if got.Bid != 106801 || got.Ask != 106802.5 {
+		t.Errorf("bid/ask = %v/%v, want 106801/106802.5", got.Bid, got.Ask)
+	}
+}
+
+func TestVertexFetchFundingRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    float64
+		wantErr bool
+	}{
+		{
+			// archive の funding_rate_x18 は24時間あたりのレート
+			name:    "daily rate",
+			payload: `{"product_id":2,"funding_rate_x18":"2447900598160952","update_time":"1680116326"}`,
+			want:    0.002447900598160952 / 24,
+		},
+		{
+			name:    "negative rate",
+			payload: `{"product_id":4,"funding_rate_x18":"-149137018059882","update_time":"1680116326"}`,
+			want:    -0.000149137018059882 / 24,
+		},
+		{
+			name:    "missing rate",
+			payload: `{"product_id":2,"update_time":"1680116326"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPayloadServer(t, map[string]string{"/": tt.payload})
+			client := NewVertexClient(WithDataURL(srv.URL), testTransport)
+
+			got, err := client.FetchFundingRate(context.Background(), "BTC-PERP")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FetchFundingRate = %v, want error", got.Rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchFundingRate: %v", err)
+			}
+			if !almostEqual(got.Rate, tt.want) {
+				t.Errorf("rate = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package httpretry
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Jitter は d/2〜d の範囲でランダムに待ち時間を返す（複数のリクエストが同時に再試行しないように）
+func Jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// RetryAfter は Retry-After ヘッダ（秒数または HTTP 日付）を解釈する。秒数は小数も受け付ける（Discord）
+func RetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil && secs >= 0 {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
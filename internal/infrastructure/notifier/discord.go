@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Discord の embed の色
+const (
+	discordColorSpread  = 0x3fb950
+	discordColorWarning = 0xd29922
+)
+
+// DiscordNotifier は Discord の Webhook に embed 形式で送信する
+type DiscordNotifier struct {
+	webhookURL   string
+	dashboardURL string
+	httpClient   *http.Client
+}
+
+func NewDiscordNotifier(webhookURL, dashboardURL string) *DiscordNotifier {
+	return &DiscordNotifier{
+		webhookURL:   webhookURL,
+		dashboardURL: dashboardURL,
+		httpClient:   newHTTPClient(),
+	}
+}
+
+func (n *DiscordNotifier) Name() string {
+	return "discord"
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, alert *Alert) error {
+	return postJSON(ctx, n.httpClient, n.webhookURL, n.buildMessage(alert))
+}
+
+func (n *DiscordNotifier) buildMessage(alert *Alert) discordMessage {
+	var fields []discordField
+	for _, f := range alertFields(alert) {
+		fields = append(fields, discordField{Name: f.Label, Value: f.Value, Inline: true})
+	}
+
+	color := discordColorWarning
+	if alert.RuleType == AlertTypeNetSpread {
+		color = discordColorSpread
+	}
+
+	return discordMessage{
+		Embeds: []discordEmbed{{
+			Title:       alertTitle(alert),
+			Description: alert.Message,
+			URL:         n.dashboardURL,
+			Color:       color,
+			Fields:      fields,
+			Timestamp:   alert.TriggeredAt.UTC().Format(time.RFC3339),
+		}},
+	}
+}
@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+)
+
+// alertField はチャット向けメッセージの1項目
+type alertField struct {
+	Label string
+	Value string
+}
+
+// alertTitle はチャット向けメッセージの見出し
+func alertTitle(alert *Alert) string {
+	switch alert.RuleType {
+	case AlertTypeNetSpread:
+		return "Arbitrage spread alert"
+	case AlertTypeStale:
+		return "Stale exchange alert"
+	case AlertTypeFundingDiff:
+		return "Funding differential alert"
+	default:
+		return "Alert"
+	}
+}
+
+// alertFields はアラートの種類に応じた詳細項目を返す
+func alertFields(alert *Alert) []alertField {
+	fields := []alertField{{Label: "Rule", Value: alert.RuleName}}
+
+	switch {
+	case alert.Spread != nil:
+		d := alert.Spread
+		fields = append(fields,
+			alertField{Label: "Buy", Value: fmt.Sprintf("%s @ %.2f", d.BuyExchange, d.BuyPrice)},
+			alertField{Label: "Sell", Value: fmt.Sprintf("%s @ %.2f", d.SellExchange, d.SellPrice)},
+			alertField{Label: "Net Spread", Value: fmt.Sprintf("%.2f (%.4f%%)", d.NetSpread, d.NetPct)},
+		)
+	case alert.Stale != nil:
+		d := alert.Stale
+		fields = append(fields,
+			alertField{Label: "Exchange", Value: d.Exchange},
+			alertField{Label: "Last Update", Value: d.LastUpdate.UTC().Format(time.RFC3339)},
+			alertField{Label: "Age", Value: fmt.Sprintf("%.0fs", d.AgeSeconds)},
+		)
+	case alert.Funding != nil:
+		d := alert.Funding
+		fields = append(fields,
+			alertField{Label: "High", Value: fmt.Sprintf("%s %.4f%%/h", d.HighExchange, d.HighRate*100)},
+			alertField{Label: "Low", Value: fmt.Sprintf("%s %.4f%%/h", d.LowExchange, d.LowRate*100)},
+			alertField{Label: "Differential", Value: fmt.Sprintf("%.4f%%/h", d.DiffPct)},
+		)
+	}
+
+	fields = append(fields, alertField{Label: "Threshold", Value: fmt.Sprintf("%g", alert.Threshold)})
+	return fields
+}
@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"btc-dex-dashboard/internal/infrastructure/httpretry"
+)
+
+// maxErrorBodySize はエラー時に読むレスポンス本文の上限
+const maxErrorBodySize = 64 << 10
+
+// StatusError は通知先が 2xx 以外を返した場合のエラー。
+// RetryAfter は通知先が指定した再送までの時間（指定がなければ0）
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+// postJSON は body を JSON で POST し、2xx 以外は *StatusError を返す。
+// 送信先の URL は秘密（Webhook の URL、Telegram の Bot トークン）を含むため、返すエラーには含めない
+func postJSON(ctx context.Context, httpClient *http.Client, endpoint string, body interface{}) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", stripURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", stripURL(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return &StatusError{StatusCode: resp.StatusCode, RetryAfter: retryAfter(resp.Header.Get("Retry-After"), body)}
+	}
+	return nil
+}
+
+// stripURL は *url.Error から URL を除いた元のエラーを返す
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+// retryAfterBody は本文で再送までの秒数を返す形式。
+// Discord はトップレベルの retry_after、Telegram は parameters.retry_after
+type retryAfterBody struct {
+	RetryAfter float64 `json:"retry_after"`
+	Parameters struct {
+		RetryAfter float64 `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// retryAfter は Retry-After ヘッダ（秒数または HTTP 日付、Slack・Discord）か本文の retry_after から再送までの時間を返す
+func retryAfter(header string, body []byte) time.Duration {
+	if d, ok := httpretry.RetryAfter(header); ok {
+		return d
+	}
+
+	var b retryAfterBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		return 0
+	}
+	secs := b.RetryAfter
+	if secs <= 0 {
+		secs = b.Parameters.RetryAfter
+	}
+	if secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
+}
@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// AlertPayloadVersion は Alert の JSON スキーマのバージョン
+const AlertPayloadVersion = 1
+
+// アラートルールの種類
+const (
+	AlertTypeNetSpread   = "net_spread"
+	AlertTypeStale       = "stale"
+	AlertTypeFundingDiff = "funding_diff"
+)
+
+// Alert は通知内容。Webhook にはこの構造体がそのまま JSON で送られる。
+// 種類に応じて Spread / Stale / Funding のいずれか1つが設定される
+type Alert struct {
+	Version     int             `json:"version"`
+	RuleName    string          `json:"rule_name"`
+	RuleType    string          `json:"rule_type"`
+	Subject     string          `json:"subject"`
+	Asset       string          `json:"asset"`
+	Message     string          `json:"message"`
+	Value       float64         `json:"value"`
+	Threshold   float64         `json:"threshold"`
+	TriggeredAt time.Time       `json:"triggered_at"`
+	Spread      *SpreadDetails  `json:"spread,omitempty"`
+	Stale       *StaleDetails   `json:"stale,omitempty"`
+	Funding     *FundingDetails `json:"funding,omitempty"`
+}
+
+// SpreadDetails は net_spread アラートの詳細
+type SpreadDetails struct {
+	BuyExchange  string  `json:"buy_exchange"`
+	SellExchange string  `json:"sell_exchange"`
+	BuyPrice     float64 `json:"buy_price"`
+	SellPrice    float64 `json:"sell_price"`
+	NetSpread    float64 `json:"net_spread"`
+	NetPct       float64 `json:"net_pct"`
+}
+
+// StaleDetails は stale アラートの詳細
+type StaleDetails struct {
+	Exchange   string    `json:"exchange"`
+	LastUpdate time.Time `json:"last_update"`
+	AgeSeconds float64   `json:"age_seconds"`
+}
+
+// FundingDetails は funding_diff アラートの詳細（レートは1時間あたり）
+type FundingDetails struct {
+	HighExchange string  `json:"high_exchange"`
+	LowExchange  string  `json:"low_exchange"`
+	HighRate     float64 `json:"high_rate"`
+	LowRate      float64 `json:"low_rate"`
+	DiffPct      float64 `json:"diff_pct"`
+}
+
+// Notifier はアラートの送信先
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, alert *Alert) error
+}
@@ -0,0 +1,199 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTelegramToken = "123456:ABC-secret-token"
+
+func testSpreadAlert() *Alert {
+	return &Alert{
+		Version:     AlertPayloadVersion,
+		RuleName:    "btc-spread",
+		RuleType:    AlertTypeNetSpread,
+		Subject:     "BTC:lighter:aster",
+		Asset:       "BTC",
+		Message:     "BTC net spread 12.50 (0.0117%) lighter -> aster",
+		Value:       12.5,
+		Threshold:   10,
+		TriggeredAt: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Spread: &SpreadDetails{
+			BuyExchange:  "lighter",
+			SellExchange: "aster",
+			BuyPrice:     106800,
+			SellPrice:    106820,
+			NetSpread:    12.5,
+			NetPct:       0.0117,
+		},
+	}
+}
+
+// captureServer は受け取ったリクエストのパスと本文を記録して 200 を返す
+func captureServer(t *testing.T) (*httptest.Server, *string, *[]byte) {
+	t.Helper()
+	var path string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s, want POST application/json", r.Method, r.Header.Get("Content-Type"))
+		}
+		path = r.URL.Path
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &path, &body
+}
+
+func TestSlackNotifierPayload(t *testing.T) {
+	srv, _, body := captureServer(t)
+	n := NewSlackNotifier(srv.URL, "https://dashboard.example")
+	if err := n.Notify(context.Background(), testSpreadAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	var msg slackMessage
+	if err := json.Unmarshal(*body, &msg); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if msg.Text != testSpreadAlert().Message {
+		t.Errorf("text = %q, want the alert message", msg.Text)
+	}
+	types := make([]string, len(msg.Blocks))
+	for i, b := range msg.Blocks {
+		types[i] = b.Type
+	}
+	if got := strings.Join(types, ","); got != "header,section,section,actions" {
+		t.Fatalf("blocks = %s, want header,section,section,actions", got)
+	}
+	if msg.Blocks[0].Text.Text != "Arbitrage spread alert" {
+		t.Errorf("header = %q", msg.Blocks[0].Text.Text)
+	}
+	if len(msg.Blocks[2].Fields) != 5 || msg.Blocks[2].Fields[1].Text != "*Buy*\nlighter @ 106800.00" {
+		t.Errorf("fields = %+v", msg.Blocks[2].Fields)
+	}
+	if msg.Blocks[3].Elements[0].URL != "https://dashboard.example" {
+		t.Errorf("button url = %q", msg.Blocks[3].Elements[0].URL)
+	}
+}
+
+func TestDiscordNotifierPayload(t *testing.T) {
+	srv, _, body := captureServer(t)
+	n := NewDiscordNotifier(srv.URL, "https://dashboard.example")
+	if err := n.Notify(context.Background(), testSpreadAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	var msg discordMessage
+	if err := json.Unmarshal(*body, &msg); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if len(msg.Embeds) != 1 {
+		t.Fatalf("embeds = %d, want 1", len(msg.Embeds))
+	}
+	e := msg.Embeds[0]
+	if e.Title != "Arbitrage spread alert" || e.Color != discordColorSpread || e.URL != "https://dashboard.example" {
+		t.Errorf("embed = %+v", e)
+	}
+	if e.Timestamp != "2026-10-18T09:30:00Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	if len(e.Fields) != 5 || e.Fields[3].Name != "Net Spread" || e.Fields[3].Value != "12.50 (0.0117%)" || !e.Fields[3].Inline {
+		t.Errorf("fields = %+v", e.Fields)
+	}
+}
+
+func TestTelegramNotifierPayload(t *testing.T) {
+	srv, path, body := captureServer(t)
+	n := NewTelegramNotifier(srv.URL, testTelegramToken, "-100123", "https://dashboard.example")
+	alert := testSpreadAlert()
+	alert.Message = "spread <lighter> & <aster>"
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if *path != "/bot"+testTelegramToken+"/sendMessage" {
+		t.Errorf("path = %q", *path)
+	}
+	var msg telegramMessage
+	if err := json.Unmarshal(*body, &msg); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if msg.ChatID != "-100123" || msg.ParseMode != "HTML" || !msg.DisableWebPagePreview {
+		t.Errorf("message = %+v", msg)
+	}
+	for _, want := range []string{
+		"<b>Arbitrage spread alert</b>",
+		"spread &lt;lighter&gt; &amp; &lt;aster&gt;",
+		"<b>Sell:</b> aster @ 106820.00",
+		`<a href="https://dashboard.example">Open dashboard</a>`,
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text %q does not contain %q", msg.Text, want)
+		}
+	}
+}
+
+func TestWebhookNotifierPayload(t *testing.T) {
+	srv, _, body := captureServer(t)
+	n := NewWebhookNotifier(srv.URL)
+	if err := n.Notify(context.Background(), testSpreadAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(*body, &got); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	for key, want := range map[string]interface{}{
+		"version":      float64(AlertPayloadVersion),
+		"rule_type":    AlertTypeNetSpread,
+		"asset":        "BTC",
+		"triggered_at": "2026-10-18T09:30:00Z",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %v", key, got[key], want)
+		}
+	}
+	spread, ok := got["spread"].(map[string]interface{})
+	if !ok || spread["buy_exchange"] != "lighter" || spread["net_pct"] != 0.0117 {
+		t.Errorf("spread = %v", got["spread"])
+	}
+	if _, ok := got["stale"]; ok {
+		t.Error("stale is set for a net_spread alert")
+	}
+}
+
+// TestTelegramNotifierErrorHidesToken は送信に失敗したエラーに Bot トークンが含まれないことを確かめる
+func TestTelegramNotifierErrorHidesToken(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+	}{
+		{name: "connection refused", baseURL: closed.URL},
+		{name: "invalid url", baseURL: "http://[::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewTelegramNotifier(tt.baseURL, testTelegramToken, "-100123", "")
+			err := n.Notify(context.Background(), testSpreadAlert())
+			if err == nil {
+				t.Fatal("Notify succeeded, want error")
+			}
+			if strings.Contains(err.Error(), testTelegramToken) {
+				t.Errorf("error %q contains the bot token", err)
+			}
+		})
+	}
+}
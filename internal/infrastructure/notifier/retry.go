@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"btc-dex-dashboard/internal/infrastructure/httpretry"
+)
+
+// RetryNotifier は送信に失敗した場合にジッター付き指数バックオフで再送する。
+// 通知先が再送までの時間（Retry-After など）を指定した場合はその時間待つ
+type RetryNotifier struct {
+	inner          Notifier
+	maxAttempts    int
+	initialBackoff time.Duration
+}
+
+func NewRetryNotifier(inner Notifier, maxAttempts int, initialBackoff time.Duration) *RetryNotifier {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &RetryNotifier{
+		inner:          inner,
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+	}
+}
+
+func (n *RetryNotifier) Name() string {
+	return n.inner.Name()
+}
+
+func (n *RetryNotifier) Notify(ctx context.Context, alert *Alert) error {
+	backoff := n.initialBackoff
+
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		err = n.inner.Notify(ctx, alert)
+		if err == nil || !isRetryable(err) || attempt >= n.maxAttempts {
+			break
+		}
+
+		wait := httpretry.Jitter(backoff)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			wait = statusErr.RetryAfter
+		}
+		// 期限までに再送できない場合は待たずに諦める
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("failed after %d attempt(s): %w", attempt, err)
+	}
+	return nil
+}
+
+// isRetryable は 429 / 5xx / 通信エラーを再送対象とする
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostJSONRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		want   time.Duration
+	}{
+		{name: "slack header", header: "30", body: "rate_limited", want: 30 * time.Second},
+		{name: "discord header and body", header: "1", body: `{"message":"You are being rate limited.","retry_after":0.65,"global":false}`, want: time.Second},
+		{name: "discord body", body: `{"message":"You are being rate limited.","retry_after":0.65,"global":false}`, want: 650 * time.Millisecond},
+		{name: "telegram parameters", body: `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 5","parameters":{"retry_after":5}}`, want: 5 * time.Second},
+		{name: "not specified", body: `{"ok":false}`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("Retry-After", tt.header)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := postJSON(context.Background(), newHTTPClient(), srv.URL, struct{}{})
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("postJSON error = %v, want *StatusError", err)
+			}
+			if statusErr.RetryAfter != tt.want {
+				t.Errorf("retry after = %v, want %v", statusErr.RetryAfter, tt.want)
+			}
+		})
+	}
+}
+
+// fakeNotifier は errs を順に返す
+type fakeNotifier struct {
+	errs  []error
+	calls []time.Time
+}
+
+func (n *fakeNotifier) Name() string {
+	return "fake"
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, alert *Alert) error {
+	n.calls = append(n.calls, time.Now())
+	if len(n.calls) <= len(n.errs) {
+		return n.errs[len(n.calls)-1]
+	}
+	return nil
+}
+
+func TestRetryNotifierHonorsRetryAfter(t *testing.T) {
+	inner := &fakeNotifier{errs: []error{&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 80 * time.Millisecond}}}
+	n := NewRetryNotifier(inner, 3, time.Millisecond)
+
+	if err := n.Notify(context.Background(), &Alert{}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(inner.calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(inner.calls))
+	}
+	if waited := inner.calls[1].Sub(inner.calls[0]); waited < 80*time.Millisecond {
+		t.Errorf("waited %v before retrying, want at least the 80ms retry-after", waited)
+	}
+}
+
+func TestRetryNotifierGivesUpBeyondDeadline(t *testing.T) {
+	inner := &fakeNotifier{errs: []error{&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}}}
+	n := NewRetryNotifier(inner, 3, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := n.Notify(ctx, &Alert{}); err == nil {
+		t.Fatal("Notify succeeded, want the 429 error")
+	}
+	if len(inner.calls) != 1 {
+		t.Errorf("calls = %d, want 1 (retry-after exceeds the deadline)", len(inner.calls))
+	}
+}
@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SlackNotifier は Incoming Webhook に Block Kit 形式で送信する
+type SlackNotifier struct {
+	webhookURL   string
+	dashboardURL string
+	httpClient   *http.Client
+}
+
+func NewSlackNotifier(webhookURL, dashboardURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL:   webhookURL,
+		dashboardURL: dashboardURL,
+		httpClient:   newHTTPClient(),
+	}
+}
+
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string         `json:"type"`
+	Text     *slackText     `json:"text,omitempty"`
+	Fields   []slackText    `json:"fields,omitempty"`
+	Elements []slackElement `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackElement struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+	URL  string     `json:"url,omitempty"`
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, alert *Alert) error {
+	return postJSON(ctx, n.httpClient, n.webhookURL, n.buildMessage(alert))
+}
+
+func (n *SlackNotifier) buildMessage(alert *Alert) slackMessage {
+	var fields []slackText
+	for _, f := range alertFields(alert) {
+		fields = append(fields, slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", f.Label, f.Value)})
+	}
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: alertTitle(alert)}},
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: alert.Message}},
+		{Type: "section", Fields: fields},
+	}
+	if n.dashboardURL != "" {
+		blocks = append(blocks, slackBlock{
+			Type: "actions",
+			Elements: []slackElement{{
+				Type: "button",
+				Text: &slackText{Type: "plain_text", Text: "Open dashboard"},
+				URL:  n.dashboardURL,
+			}},
+		})
+	}
+
+	return slackMessage{
+		Text:   alert.Message, // 通知プレビュー用
+		Blocks: blocks,
+	}
+}
@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+const defaultTelegramBaseURL = "https://api.telegram.org"
+
+// TelegramNotifier は Bot API の sendMessage で送信する
+type TelegramNotifier struct {
+	baseURL      string
+	botToken     string
+	chatID       string
+	dashboardURL string
+	httpClient   *http.Client
+}
+
+// NewTelegramNotifier は baseURL が空の場合に公式の Bot API を使う
+func NewTelegramNotifier(baseURL, botToken, chatID, dashboardURL string) *TelegramNotifier {
+	if baseURL == "" {
+		baseURL = defaultTelegramBaseURL
+	}
+	return &TelegramNotifier{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		botToken:     botToken,
+		chatID:       chatID,
+		dashboardURL: dashboardURL,
+		httpClient:   newHTTPClient(),
+	}
+}
+
+func (n *TelegramNotifier) Name() string {
+	return "telegram"
+}
+
+type telegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, alert *Alert) error {
+	url := fmt.Sprintf("%s/bot%s/sendMessage", n.baseURL, n.botToken)
+	return postJSON(ctx, n.httpClient, url, n.buildMessage(alert))
+}
+
+func (n *TelegramNotifier) buildMessage(alert *Alert) telegramMessage {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<b>%s</b>\n%s\n\n", html.EscapeString(alertTitle(alert)), html.EscapeString(alert.Message))
+	for _, f := range alertFields(alert) {
+		fmt.Fprintf(&b, "<b>%s:</b> %s\n", html.EscapeString(f.Label), html.EscapeString(f.Value))
+	}
+	if n.dashboardURL != "" {
+		fmt.Fprintf(&b, "\n<a href=\"%s\">Open dashboard</a>", html.EscapeString(n.dashboardURL))
+	}
+
+	return telegramMessage{
+		ChatID:                n.chatID,
+		Text:                  b.String(),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	}
+}
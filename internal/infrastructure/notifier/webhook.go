@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookNotifier は Alert を JSON のまま任意の URL に POST する
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: newHTTPClient(),
+	}
+}
+
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	return postJSON(ctx, n.httpClient, n.url, alert)
+}
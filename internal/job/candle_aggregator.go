@@ -0,0 +1,167 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// CandleAggregator は生の価格データから 1m 足を作り、1m 足から 5m / 1h 足を順に集計する
+type CandleAggregator struct {
+	marketRepo repository.MarketRepository
+	priceRepo  repository.PriceRepository
+	candleRepo repository.CandleRepository
+	backfill   time.Duration // 足がまだない場合に遡る期間
+}
+
+func NewCandleAggregator(
+	marketRepo repository.MarketRepository,
+	priceRepo repository.PriceRepository,
+	candleRepo repository.CandleRepository,
+	backfill time.Duration,
+) *CandleAggregator {
+	return &CandleAggregator{
+		marketRepo: marketRepo,
+		priceRepo:  priceRepo,
+		candleRepo: candleRepo,
+		backfill:   backfill,
+	}
+}
+
+func (a *CandleAggregator) FetchAndSaveAll(ctx context.Context) {
+	markets, err := a.marketRepo.FindAll(ctx)
+	if err != nil {
+		log.Printf("[candles] failed to get markets: %v", err)
+		return
+	}
+
+	now := time.Now()
+	total := 0
+
+	for _, market := range markets {
+		for i, ci := range model.CandleIntervals {
+			start := a.startFor(ctx, market.ID, ci, now)
+
+			var candles []model.Candle
+			if i == 0 {
+				prices, err := a.priceRepo.FindByMarketAndTimeRange(ctx, market.ID, start, now)
+				if err != nil {
+					log.Printf("[candles] [%s] failed to get prices: %v", market.Exchange.Key, err)
+					break
+				}
+				candles = aggregatePrices(prices, market.ID, ci)
+			} else {
+				src := model.CandleIntervals[i-1]
+				sub, err := a.candleRepo.FindByMarketAndTimeRange(ctx, market.ID, src.Name, start, now)
+				if err != nil {
+					log.Printf("[candles] [%s] failed to get %s candles: %v", market.Exchange.Key, src.Name, err)
+					break
+				}
+				candles = rollupCandles(sub, market.ID, ci)
+			}
+
+			if err := a.candleRepo.UpsertBatch(ctx, candles); err != nil {
+				log.Printf("[candles] [%s] failed to save %s candles: %v", market.Exchange.Key, ci.Name, err)
+				break
+			}
+			total += len(candles)
+		}
+	}
+
+	log.Printf("[candles] upserted %d candles", total)
+}
+
+// startFor は集計の開始時刻を返す。最新の足は未確定の可能性があるため、その足から再集計する
+func (a *CandleAggregator) startFor(ctx context.Context, marketID uint, ci model.CandleInterval, now time.Time) time.Time {
+	latest, err := a.candleRepo.FindLatestByMarket(ctx, marketID, ci.Name)
+	if err != nil {
+		return now.Add(-a.backfill).Truncate(ci.Duration)
+	}
+	return latest.Ts
+}
+
+// aggregatePrices は時刻順の価格データを足に集計する
+func aggregatePrices(prices []model.Price, marketID uint, ci model.CandleInterval) []model.Candle {
+	var candles []model.Candle
+	var current *model.Candle
+
+	for _, p := range prices {
+		ts := p.Ts.Truncate(ci.Duration)
+		mid := (p.Bid + p.Ask) / 2
+
+		if current == nil || !current.Ts.Equal(ts) {
+			candles = append(candles, model.Candle{
+				MarketID: marketID,
+				Interval: ci.Name,
+				Ts:       ts,
+				BidOpen:  p.Bid,
+				BidHigh:  p.Bid,
+				BidLow:   p.Bid,
+				AskOpen:  p.Ask,
+				AskHigh:  p.Ask,
+				AskLow:   p.Ask,
+				MidOpen:  mid,
+				MidHigh:  mid,
+				MidLow:   mid,
+			})
+			current = &candles[len(candles)-1]
+		}
+
+		current.BidHigh = max(current.BidHigh, p.Bid)
+		current.BidLow = min(current.BidLow, p.Bid)
+		current.BidClose = p.Bid
+		current.AskHigh = max(current.AskHigh, p.Ask)
+		current.AskLow = min(current.AskLow, p.Ask)
+		current.AskClose = p.Ask
+		current.MidHigh = max(current.MidHigh, mid)
+		current.MidLow = min(current.MidLow, mid)
+		current.MidClose = mid
+		current.Count++
+	}
+
+	return candles
+}
+
+// rollupCandles は時刻順の短い足をより長い足に集計する
+func rollupCandles(sub []model.Candle, marketID uint, ci model.CandleInterval) []model.Candle {
+	var candles []model.Candle
+	var current *model.Candle
+
+	for _, c := range sub {
+		ts := c.Ts.Truncate(ci.Duration)
+
+		if current == nil || !current.Ts.Equal(ts) {
+			candles = append(candles, model.Candle{
+				MarketID: marketID,
+				Interval: ci.Name,
+				Ts:       ts,
+				BidOpen:  c.BidOpen,
+				BidHigh:  c.BidHigh,
+				BidLow:   c.BidLow,
+				AskOpen:  c.AskOpen,
+				AskHigh:  c.AskHigh,
+				AskLow:   c.AskLow,
+				MidOpen:  c.MidOpen,
+				MidHigh:  c.MidHigh,
+				MidLow:   c.MidLow,
+			})
+			current = &candles[len(candles)-1]
+		}
+
+		current.BidHigh = max(current.BidHigh, c.BidHigh)
+		current.BidLow = min(current.BidLow, c.BidLow)
+		current.BidClose = c.BidClose
+		current.AskHigh = max(current.AskHigh, c.AskHigh)
+		current.AskLow = min(current.AskLow, c.AskLow)
+		current.AskClose = c.AskClose
+		current.MidHigh = max(current.MidHigh, c.MidHigh)
+		current.MidLow = min(current.MidLow, c.MidLow)
+		current.MidClose = c.MidClose
+		current.Count += c.Count
+	}
+
+	return candles
+}
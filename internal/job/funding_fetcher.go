@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/infrastructure/dex"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// fundingTsResolution は Funding Rate の保存時刻の丸め単位。
+// 同じ単位内での再取得は同一レコードへの上書きになる
+const fundingTsResolution = time.Minute
+
+type FundingFetcher struct {
+	targets     []FetchTarget
+	fundingRepo repository.FundingRateRepository
+	timeout     time.Duration // 1マーケットあたりの取得の期限
+}
+
+func NewFundingFetcher(
+	targets []FetchTarget,
+	fundingRepo repository.FundingRateRepository,
+	timeout time.Duration,
+) *FundingFetcher {
+	return &FundingFetcher{
+		targets:     targets,
+		fundingRepo: fundingRepo,
+		timeout:     timeout,
+	}
+}
+
+type fundingResult struct {
+	target FetchTarget
+	data   *dex.FundingRateData
+	err    error
+}
+
+func (f *FundingFetcher) FetchAndSaveAll(ctx context.Context) {
+	results := make(chan fundingResult, len(f.targets))
+	var wg sync.WaitGroup
+
+	// 全マーケットの Funding Rate を並行して取得
+	for _, target := range f.targets {
+		wg.Add(1)
+		go func(t FetchTarget) {
+			defer wg.Done()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+
+			data, err := t.Client.FetchFundingRate(fetchCtx, t.Symbol)
+			results <- fundingResult{
+				target: t,
+				data:   data,
+				err:    err,
+			}
+		}(target)
+	}
+
+	// 全 goroutine の完了を待ってから channel を閉じる
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// 結果を受信して DB に保存
+	for result := range results {
+		label := result.target.label()
+		if result.err != nil {
+			log.Printf("[%s] failed to fetch funding rate: %v", label, result.err)
+			continue
+		}
+
+		rate := &model.FundingRate{
+			MarketID: result.target.MarketID,
+			Ts:       result.data.Ts.Truncate(fundingTsResolution),
+			Rate:     result.data.Rate,
+		}
+
+		if err := f.fundingRepo.Upsert(ctx, rate); err != nil {
+			log.Printf("[%s] failed to save funding rate: %v", label, err)
+			continue
+		}
+
+		log.Printf("[%s] saved funding rate: %.8f", label, result.data.Rate)
+	}
+}
@@ -0,0 +1,103 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/infrastructure/dex"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// marketStatsTsResolution は統計の保存時刻の丸め単位。
+// 同じ単位内での再取得は同一レコードへの上書きになる
+const marketStatsTsResolution = time.Minute
+
+// MarketStatsFetcher はマーク価格・インデックス価格・建玉・出来高を定期的に取得する。
+// targets の Client は dex.MarketStatsClient を実装している必要がある
+type MarketStatsFetcher struct {
+	targets   []FetchTarget
+	statsRepo repository.MarketStatsRepository
+	timeout   time.Duration // 1マーケットあたりの取得の期限
+}
+
+func NewMarketStatsFetcher(
+	targets []FetchTarget,
+	statsRepo repository.MarketStatsRepository,
+	timeout time.Duration,
+) *MarketStatsFetcher {
+	return &MarketStatsFetcher{
+		targets:   targets,
+		statsRepo: statsRepo,
+		timeout:   timeout,
+	}
+}
+
+type marketStatsResult struct {
+	target FetchTarget
+	data   *dex.MarketStats
+	err    error
+}
+
+func (f *MarketStatsFetcher) FetchAndSaveAll(ctx context.Context) {
+	results := make(chan marketStatsResult, len(f.targets))
+	var wg sync.WaitGroup
+
+	// 全マーケットの統計を並行して取得
+	for _, target := range f.targets {
+		wg.Add(1)
+		go func(t FetchTarget) {
+			defer wg.Done()
+
+			client, ok := t.Client.(dex.MarketStatsClient)
+			if !ok {
+				results <- marketStatsResult{target: t, err: fmt.Errorf("market stats not supported")}
+				return
+			}
+
+			fetchCtx, cancel := context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+
+			data, err := client.FetchMarketStats(fetchCtx, t.Symbol)
+			results <- marketStatsResult{
+				target: t,
+				data:   data,
+				err:    err,
+			}
+		}(target)
+	}
+
+	// 全 goroutine の完了を待ってから channel を閉じる
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// 結果を受信して DB に保存
+	for result := range results {
+		label := result.target.label()
+		if result.err != nil {
+			log.Printf("[%s] failed to fetch market stats: %v", label, result.err)
+			continue
+		}
+
+		stats := &model.MarketStats{
+			MarketID:     result.target.MarketID,
+			Ts:           result.data.Ts.Truncate(marketStatsTsResolution),
+			MarkPrice:    result.data.MarkPrice,
+			IndexPrice:   result.data.IndexPrice,
+			OpenInterest: result.data.OpenInterest,
+			Volume24h:    result.data.Volume24h,
+		}
+
+		if err := f.statsRepo.Upsert(ctx, stats); err != nil {
+			log.Printf("[%s] failed to save market stats: %v", label, err)
+			continue
+		}
+
+		log.Printf("[%s] saved market stats", label)
+	}
+}
@@ -4,53 +4,110 @@ import (
 	"context"
 	"log"
 	"sync"
+	"time"
 
 	"btc-dex-dashboard/internal/domain/model"
 	"btc-dex-dashboard/internal/infrastructure/dex"
 	"btc-dex-dashboard/internal/repository"
 )
 
+// RoundListener は価格取得ラウンドの完了時に RunListeners の goroutine から呼ばれる。
+// 通知はラウンドの結果を持たないため、最新の価格は DB から読む
+type RoundListener interface {
+	OnPriceRound(ctx context.Context)
+}
+
+// HealthRecorder はマーケットごとの価格取得の成否を受け取る。
+// latency は REST の往復時間で、WebSocket のキャッシュから返した場合は0
+type HealthRecorder interface {
+	RecordSuccess(exchangeKey, symbol string, latency time.Duration, at time.Time)
+	RecordFailure(exchangeKey, symbol string, err error, at time.Time)
+}
+
 type PriceFetcher struct {
-	clients   []dex.DexClient
+	targets   []FetchTarget
 	priceRepo repository.PriceRepository
-	marketIDs map[string]uint // DEX名 → MarketID のマッピング
+	health    HealthRecorder
+	timeout   time.Duration // 1取引所あたりの取得の期限（遅い取引所でラウンドが止まらないように）
+	listeners []RoundListener
+	rounds    chan struct{} // 未処理のラウンド完了通知（1件まで）
+
+	// lastReceived はマーケットごとに最後に保存した気配値の受信時刻（FetchAndSaveAll の goroutine のみが触る）
+	lastReceived map[uint]time.Time
 }
 
 func NewPriceFetcher(
-	clients []dex.DexClient,
+	targets []FetchTarget,
 	priceRepo repository.PriceRepository,
-	marketIDs map[string]uint,
+	health HealthRecorder,
+	timeout time.Duration,
 ) *PriceFetcher {
 	return &PriceFetcher{
-		clients:   clients,
+		targets:   targets,
 		priceRepo: priceRepo,
-		marketIDs: marketIDs,
+		health:    health,
+		timeout:   timeout,
+		rounds:    make(chan struct{}, 1),
+
+		lastReceived: make(map[uint]time.Time),
+	}
+}
+
+// AddListener はラウンド完了時の通知先を登録する。RunListeners の開始前に呼ぶ
+func (f *PriceFetcher) AddListener(l RoundListener) {
+	f.listeners = append(f.listeners, l)
+}
+
+// RunListeners はラウンド完了の通知を受けて登録済みの RoundListener を順に呼ぶ。ctx が終わるまで戻らない。
+// 価格取得とは別の goroutine で動かし、遅い通知先があっても価格取得を遅らせない
+func (f *PriceFetcher) RunListeners(ctx context.Context) {
+	for {
+		select {
+		case <-f.rounds:
+			for _, l := range f.listeners {
+				l.OnPriceRound(ctx)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// notifyRound はラウンド完了を通知する。前の通知が未処理ならまとめる（通知先は最新の価格を読むので取りこぼしはない）
+func (f *PriceFetcher) notifyRound() {
+	select {
+	case f.rounds <- struct{}{}:
+	default:
+		log.Printf("price round listeners are still busy, coalescing this round")
 	}
 }
 
 type priceResult struct {
-	dexName string
-	data    *dex.PriceData
-	err     error
+	target FetchTarget
+	data   *dex.PriceData
+	err    error
 }
 
 func (f *PriceFetcher) FetchAndSaveAll(ctx context.Context) {
-	results := make(chan priceResult, len(f.clients))
+	results := make(chan priceResult, len(f.targets))
 	var wg sync.WaitGroup
 
-	// 全 DEX から並行して価格を取得
-	for _, client := range f.clients {
+	// 全マーケットの価格を並行して取得
+	for _, target := range f.targets {
 		wg.Add(1)
-		go func(c dex.DexClient) {
+		go func(t FetchTarget) {
 			defer wg.Done()
 
-			data, err := c.FetchBTCPerpPrice(ctx)
+			fetchCtx, cancel := context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+
+			data, err := t.Client.FetchPrice(fetchCtx, t.Symbol)
 			results <- priceResult{
-				dexName: c.Name(),
-				data:    data,
-				err:     err,
+				target: t,
+				data:   data,
+				err:    err,
 			}
-		}(client)
+		}(target)
 	}
 
 	// 全 goroutine の完了を待ってから channel を閉じる
@@ -61,29 +118,46 @@ func (f *PriceFetcher) FetchAndSaveAll(ctx context.Context) {
 
 	// 結果を受信して DB に保存
 	for result := range results {
+		label := result.target.label()
+		exchangeKey := result.target.Client.Name()
 		if result.err != nil {
-			log.Printf("[%s] failed to fetch price: %v", result.dexName, result.err)
+			log.Printf("[%s] failed to fetch price: %v", label, result.err)
+			f.health.RecordFailure(exchangeKey, result.target.Symbol, result.err, time.Now())
 			continue
 		}
-
-		marketID, ok := f.marketIDs[result.dexName]
-		if !ok {
-			log.Printf("[%s] market ID not found", result.dexName)
+		// 成功時刻は受信時刻ではなく記録した時刻にする（WebSocket のキャッシュは受信時刻が古い）
+		latency := result.data.Latency
+		if result.data.Cached {
+			latency = 0
+		}
+		f.health.RecordSuccess(exchangeKey, result.target.Symbol, latency, time.Now())
+		if last, ok := f.lastReceived[result.target.MarketID]; ok && result.data.Cached && last.Equal(result.data.Ts) {
+			// 前回保存してから WebSocket の更新がない気配値は保存しない。
+			// 止まったストリームの気配値を新しい行として残すと、履歴や古さの判定で新しく見えてしまう
 			continue
 		}
 
 		price := &model.Price{
-			MarketID: marketID,
-			Ts:       result.data.Ts,
-			Bid:      result.data.Bid,
-			Ask:      result.data.Ask,
+			MarketID:  result.target.MarketID,
+			Ts:        result.data.Ts,
+			Bid:       result.data.Bid,
+			Ask:       result.data.Ask,
+			LatencyMs: float64(result.data.Latency) / float64(time.Millisecond),
+		}
+		if !result.data.ExchangeTs.IsZero() {
+			exchangeTs := result.data.ExchangeTs
+			price.ExchangeTs = &exchangeTs
 		}
 
 		if err := f.priceRepo.Create(ctx, price); err != nil {
-			log.Printf("[%s] failed to save price: %v", result.dexName, err)
+			log.Printf("[%s] failed to save price: %v", label, err)
 			continue
 		}
+		f.lastReceived[result.target.MarketID] = result.data.Ts
 
-		log.Printf("[%s] saved: bid=%.2f, ask=%.2f", result.dexName, result.data.Bid, result.data.Ask)
+		log.Printf("[%s] saved: bid=%.2f, ask=%.2f", label, result.data.Bid, result.data.Ask)
 	}
+
+	// ラウンド完了を通知
+	f.notifyRound()
 }
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"btc-dex-dashboard/internal/repository"
+)
+
+// defaultRetentionBatchSize はバッチサイズ未指定時の1回あたりの削除件数
+const defaultRetentionBatchSize = 5000
+
+// retentionBatchPause はバッチ削除の間隔。価格取得ジョブの書き込みが割り込めるようにする
+const retentionBatchPause = 100 * time.Millisecond
+
+// RetentionPolicy はデータ種別ごとの保持期間。0 は無期限
+type RetentionPolicy struct {
+	RawPrices time.Duration
+	Candles   map[string]time.Duration // 足の種類 → 保持期間
+	BatchSize int
+}
+
+// RetentionJob は保持期間を過ぎた価格データと足をバッチ単位で削除する
+type RetentionJob struct {
+	priceRepo  repository.PriceRepository
+	candleRepo repository.CandleRepository
+	policy     RetentionPolicy
+}
+
+func NewRetentionJob(
+	priceRepo repository.PriceRepository,
+	candleRepo repository.CandleRepository,
+	policy RetentionPolicy,
+) *RetentionJob {
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = defaultRetentionBatchSize
+	}
+	return &RetentionJob{
+		priceRepo:  priceRepo,
+		candleRepo: candleRepo,
+		policy:     policy,
+	}
+}
+
+func (j *RetentionJob) Run(ctx context.Context) {
+	now := time.Now()
+
+	if j.policy.RawPrices > 0 {
+		before := now.Add(-j.policy.RawPrices)
+		removed, err := j.deleteInBatches(ctx, func(limit int) (int64, error) {
+			return j.priceRepo.DeleteOlderThan(ctx, before, limit)
+		})
+		if err != nil {
+			log.Printf("[retention] failed to delete prices: %v", err)
+		}
+		if removed > 0 {
+			log.Printf("[retention] removed %d prices older than %s", removed, before.Format(time.RFC3339))
+		}
+	}
+
+	for interval, keep := range j.policy.Candles {
+		if keep <= 0 {
+			continue
+		}
+		before := now.Add(-keep)
+		removed, err := j.deleteInBatches(ctx, func(limit int) (int64, error) {
+			return j.candleRepo.DeleteOlderThan(ctx, interval, before, limit)
+		})
+		if err != nil {
+			log.Printf("[retention] failed to delete %s candles: %v", interval, err)
+		}
+		if removed > 0 {
+			log.Printf("[retention] removed %d %s candles older than %s", removed, interval, before.Format(time.RFC3339))
+		}
+	}
+}
+
+// deleteInBatches は削除件数が0になるまで deleteFn を繰り返し、合計削除件数を返す
+func (j *RetentionJob) deleteInBatches(ctx context.Context, deleteFn func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		n, err := deleteFn(j.policy.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < int64(j.policy.BatchSize) {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(retentionBatchPause):
+		}
+	}
+}
@@ -6,14 +6,28 @@ import (
 	"time"
 )
 
+// Fetcher は Scheduler から定期実行されるジョブ
+type Fetcher interface {
+	FetchAndSaveAll(ctx context.Context)
+}
+
+// FetcherFunc は関数を Fetcher として扱うためのアダプタ
+type FetcherFunc func(ctx context.Context)
+
+func (f FetcherFunc) FetchAndSaveAll(ctx context.Context) {
+	f(ctx)
+}
+
 type Scheduler struct {
-	fetcher  *PriceFetcher
+	name     string
+	fetcher  Fetcher
 	interval time.Duration
 	stopCh   chan struct{}
 }
 
-func NewScheduler(fetcher *PriceFetcher, interval time.Duration) *Scheduler {
+func NewScheduler(name string, fetcher Fetcher, interval time.Duration) *Scheduler {
 	return &Scheduler{
+		name:     name,
 		fetcher:  fetcher,
 		interval: interval,
 		stopCh:   make(chan struct{}),
@@ -24,7 +38,7 @@ func (s *Scheduler) Start(ctx context.Context) {
 	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 
-	log.Printf("Scheduler started: fetching prices every %v", s.interval)
+	log.Printf("Scheduler started: fetching %s every %v", s.name, s.interval)
 
 	// 起動直後に1回実行
 	s.fetcher.FetchAndSaveAll(ctx)
@@ -34,10 +48,10 @@ func (s *Scheduler) Start(ctx context.Context) {
 		case <-ticker.C:
 			s.fetcher.FetchAndSaveAll(ctx)
 		case <-s.stopCh:
-			log.Println("Scheduler stopped")
+			log.Printf("Scheduler stopped: %s", s.name)
 			return
 		case <-ctx.Done():
-			log.Println("Scheduler stopped by context")
+			log.Printf("Scheduler stopped by context: %s", s.name)
 			return
 		}
 	}
@@ -0,0 +1,15 @@
+package job
+
+import "btc-dex-dashboard/internal/infrastructure/dex"
+
+// FetchTarget は取得対象のマーケット（取引所 × 銘柄）
+type FetchTarget struct {
+	Client   dex.DexClient
+	MarketID uint
+	Symbol   string // 取引所ごとのシンボル
+}
+
+// label はログ用の「取引所 シンボル」
+func (t FetchTarget) label() string {
+	return t.Client.Name() + " " + t.Symbol
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"btc-dex-dashboard/internal/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlertRuleRepository interface {
+	FindAll(ctx context.Context) ([]model.AlertRule, error)
+	Create(ctx context.Context, rule *model.AlertRule) error
+	UpsertByName(ctx context.Context, rule *model.AlertRule) error
+	DisableConfigRulesExcept(ctx context.Context, names []string) (int64, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type GormAlertRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAlertRuleRepository(db *gorm.DB) *GormAlertRuleRepository {
+	return &GormAlertRuleRepository{db: db}
+}
+
+func (r *GormAlertRuleRepository) FindAll(ctx context.Context) ([]model.AlertRule, error) {
+	var rules []model.AlertRule
+	result := r.db.WithContext(ctx).Order("id ASC").Find(&rules)
+	return rules, result.Error
+}
+
+func (r *GormAlertRuleRepository) Create(ctx context.Context, rule *model.AlertRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// UpsertByName は同名のルールがあれば内容を上書きする
+func (r *GormAlertRuleRepository) UpsertByName(ctx context.Context, rule *model.AlertRule) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"type", "threshold", "for_seconds", "cooldown_seconds", "asset",
+				"exchange", "buy_exchange", "sell_exchange", "disabled", "from_config", "updated_at",
+			}),
+		}).
+		Create(rule).Error
+}
+
+// DisableConfigRulesExcept は config.yaml 由来のルールのうち names にないものを無効にし、無効にした件数を返す
+func (r *GormAlertRuleRepository) DisableConfigRulesExcept(ctx context.Context, names []string) (int64, error) {
+	removed := r.db.WithContext(ctx).Model(&model.AlertRule{}).
+		Where("from_config = ? AND disabled = ?", true, false)
+	if len(names) > 0 {
+		removed = removed.Where("name NOT IN ?", names)
+	}
+	result := removed.Update("disabled", true)
+	return result.RowsAffected, result.Error
+}
+
+func (r *GormAlertRuleRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&model.AlertRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CandleRepository interface {
+	FindByMarketAndTimeRange(ctx context.Context, marketID uint, interval string, from, to time.Time) ([]model.Candle, error)
+	FindLatestByMarket(ctx context.Context, marketID uint, interval string) (*model.Candle, error)
+	UpsertBatch(ctx context.Context, candles []model.Candle) error
+	DeleteOlderThan(ctx context.Context, interval string, before time.Time, limit int) (int64, error)
+}
+
+type GormCandleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCandleRepository(db *gorm.DB) *GormCandleRepository {
+	return &GormCandleRepository{db: db}
+}
+
+func (r *GormCandleRepository) FindByMarketAndTimeRange(ctx context.Context, marketID uint, interval string, from, to time.Time) ([]model.Candle, error) {
+	var candles []model.Candle
+	result := r.db.WithContext(ctx).
+		Where("market_id = ? AND interval = ? AND ts >= ? AND ts <= ?", marketID, interval, from, to).
+		Order("ts ASC").
+		Find(&candles)
+	return candles, result.Error
+}
+
+func (r *GormCandleRepository) FindLatestByMarket(ctx context.Context, marketID uint, interval string) (*model.Candle, error) {
+	var candle model.Candle
+	result := r.db.WithContext(ctx).
+		Where("market_id = ? AND interval = ?", marketID, interval).
+		Order("ts DESC").
+		First(&candle)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &candle, nil
+}
+
+// UpsertBatch は (market_id, interval, ts) が重複する足を上書きする
+func (r *GormCandleRepository) UpsertBatch(ctx context.Context, candles []model.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "market_id"}, {Name: "interval"}, {Name: "ts"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"bid_open", "bid_high", "bid_low", "bid_close",
+				"ask_open", "ask_high", "ask_low", "ask_close",
+				"mid_open", "mid_high", "mid_low", "mid_close",
+				"count", "updated_at",
+			}),
+		}).
+		CreateInBatches(&candles, 500).Error
+}
+
+// DeleteOlderThan は before より古い指定足を最大 limit 件削除し、削除件数を返す
+func (r *GormCandleRepository) DeleteOlderThan(ctx context.Context, interval string, before time.Time, limit int) (int64, error) {
+	subQuery := r.db.Model(&model.Candle{}).Select("id").Where("interval = ? AND ts < ?", interval, before).Limit(limit)
+	result := r.db.WithContext(ctx).Where("id IN (?)", subQuery).Delete(&model.Candle{})
+	return result.RowsAffected, result.Error
+}
@@ -25,9 +25,10 @@ func NewGormExchangeRepository(db *gorm.DB) *GormExchangeRepository {
 	return &GormExchangeRepository{db: db}
 }
 
+// FindAll は有効な取引所を返す
 func (r *GormExchangeRepository) FindAll(ctx context.Context) ([]model.Exchange, error) {
 	var exchanges []model.Exchange
-	result := r.db.WithContext(ctx).Find(&exchanges)
+	result := r.db.WithContext(ctx).Where("disabled = ?", false).Find(&exchanges)
 	return exchanges, result.Error
 }
 
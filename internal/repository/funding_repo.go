@@ -7,12 +7,14 @@ import (
 	"btc-dex-dashboard/internal/domain/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FundingRateRepository interface {
 	FindByMarketAndTimeRange(ctx context.Context, marketID uint, from, to time.Time) ([]model.FundingRate, error)
 	FindLatestByMarket(ctx context.Context, marketID uint) (*model.FundingRate, error)
 	Create(ctx context.Context, rate *model.FundingRate) error
+	Upsert(ctx context.Context, rate *model.FundingRate) error
 }
 
 type GormFundingRateRepository struct {
@@ -46,4 +48,14 @@ func (r *GormFundingRateRepository) FindLatestByMarket(ctx context.Context, mark
 
 func (r *GormFundingRateRepository) Create(ctx context.Context, rate *model.FundingRate) error {
 	return r.db.WithContext(ctx).Create(rate).Error
-}
\ No newline at end of file
+}
+
+// Upsert は (market_id, ts) が重複する場合はレートを上書きする
+func (r *GormFundingRateRepository) Upsert(ctx context.Context, rate *model.FundingRate) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "market_id"}, {Name: "ts"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate"}),
+		}).
+		Create(rate).Error
+}
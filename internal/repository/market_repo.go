@@ -10,6 +10,7 @@ import (
 
 type MarketRepository interface {
 	FindAll(ctx context.Context) ([]model.Market, error)
+	FindByBaseAsset(ctx context.Context, asset string) ([]model.Market, error)
 	FindByExchangeID(ctx context.Context, exchangeID uint) ([]model.Market, error)
 	FindByID(ctx context.Context, id uint) (*model.Market, error)
 	Create(ctx context.Context, market *model.Market) error
@@ -23,15 +24,23 @@ func NewGormMarketRepository(db *gorm.DB) *GormMarketRepository {
 	return &GormMarketRepository{db: db}
 }
 
+// FindAll は有効なマーケットを返す（FindByID 以外は無効なマーケットを返さない）
 func (r *GormMarketRepository) FindAll(ctx context.Context) ([]model.Market, error) {
 	var markets []model.Market
-	result := r.db.WithContext(ctx).Preload("Exchange").Find(&markets)
+	result := r.db.WithContext(ctx).Preload("Exchange").Where("disabled = ?", false).Find(&markets)
+	return markets, result.Error
+}
+
+// FindByBaseAsset は銘柄（BTC など）の全取引所のマーケットを返す
+func (r *GormMarketRepository) FindByBaseAsset(ctx context.Context, asset string) ([]model.Market, error) {
+	var markets []model.Market
+	result := r.db.WithContext(ctx).Preload("Exchange").Where("base_asset = ? AND disabled = ?", asset, false).Find(&markets)
 	return markets, result.Error
 }
 
 func (r *GormMarketRepository) FindByExchangeID(ctx context.Context, exchangeID uint) ([]model.Market, error) {
 	var markets []model.Market
-	result := r.db.WithContext(ctx).Where("exchange_id = ?", exchangeID).Find(&markets)
+	result := r.db.WithContext(ctx).Where("exchange_id = ? AND disabled = ?", exchangeID, false).Find(&markets)
 	return markets, result.Error
 }
 
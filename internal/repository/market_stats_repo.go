@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"btc-dex-dashboard/internal/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MarketStatsRepository interface {
+	FindLatestByMarket(ctx context.Context, marketID uint) (*model.MarketStats, error)
+	Upsert(ctx context.Context, stats *model.MarketStats) error
+}
+
+type GormMarketStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewGormMarketStatsRepository(db *gorm.DB) *GormMarketStatsRepository {
+	return &GormMarketStatsRepository{db: db}
+}
+
+func (r *GormMarketStatsRepository) FindLatestByMarket(ctx context.Context, marketID uint) (*model.MarketStats, error) {
+	var stats model.MarketStats
+	result := r.db.WithContext(ctx).
+		Where("market_id = ?", marketID).
+		Order("ts DESC").
+		First(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &stats, nil
+}
+
+// Upsert は (market_id, ts) が重複する場合は統計を上書きする
+func (r *GormMarketStatsRepository) Upsert(ctx context.Context, stats *model.MarketStats) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "market_id"}, {Name: "ts"}},
+			DoUpdates: clause.AssignmentColumns([]string{"mark_price", "index_price", "open_interest", "volume_24h"}),
+		}).
+		Create(stats).Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// OpportunityFilter はアービトラージ機会の検索条件。ゼロ値の項目は条件に含めない
+type OpportunityFilter struct {
+	Asset        string
+	BuyExchange  string
+	SellExchange string
+	MinDuration  time.Duration
+	From         time.Time // この時刻以降に終了（または継続中）
+	To           time.Time // この時刻以前に開始
+	Limit        int
+}
+
+type OpportunityRepository interface {
+	FindOpen(ctx context.Context) ([]model.Opportunity, error)
+	FindByFilter(ctx context.Context, filter OpportunityFilter) ([]model.Opportunity, error)
+	Create(ctx context.Context, opp *model.Opportunity) error
+	Update(ctx context.Context, opp *model.Opportunity) error
+}
+
+type GormOpportunityRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOpportunityRepository(db *gorm.DB) *GormOpportunityRepository {
+	return &GormOpportunityRepository{db: db}
+}
+
+func (r *GormOpportunityRepository) FindOpen(ctx context.Context) ([]model.Opportunity, error) {
+	var opps []model.Opportunity
+	result := r.db.WithContext(ctx).Where("ended_at IS NULL").Find(&opps)
+	return opps, result.Error
+}
+
+func (r *GormOpportunityRepository) FindByFilter(ctx context.Context, filter OpportunityFilter) ([]model.Opportunity, error) {
+	query := r.db.WithContext(ctx)
+
+	if filter.Asset != "" {
+		query = query.Where("asset = ?", filter.Asset)
+	}
+	if filter.BuyExchange != "" {
+		query = query.Where("buy_exchange = ?", filter.BuyExchange)
+	}
+	if filter.SellExchange != "" {
+		query = query.Where("sell_exchange = ?", filter.SellExchange)
+	}
+	if filter.MinDuration > 0 {
+		// 継続中のものは現在までの経過時間で判定する
+		query = query.Where(
+			"(ended_at IS NOT NULL AND duration_seconds >= ?) OR (ended_at IS NULL AND started_at <= ?)",
+			filter.MinDuration.Seconds(), time.Now().Add(-filter.MinDuration),
+		)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("ended_at IS NULL OR ended_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("started_at <= ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var opps []model.Opportunity
+	result := query.Order("started_at DESC").Find(&opps)
+	return opps, result.Error
+}
+
+func (r *GormOpportunityRepository) Create(ctx context.Context, opp *model.Opportunity) error {
+	return r.db.WithContext(ctx).Create(opp).Error
+}
+
+func (r *GormOpportunityRepository) Update(ctx context.Context, opp *model.Opportunity) error {
+	return r.db.WithContext(ctx).Save(opp).Error
+}
@@ -14,6 +14,7 @@ type PriceRepository interface {
 	FindLatestByMarket(ctx context.Context, marketID uint) (*model.Price, error)
 	Create(ctx context.Context, price *model.Price) error
 	CreateBatch(ctx context.Context, prices []model.Price) error
+	DeleteOlderThan(ctx context.Context, before time.Time, limit int) (int64, error)
 }
 
 type GormPriceRepository struct {
@@ -52,3 +53,10 @@ func (r *GormPriceRepository) Create(ctx context.Context, price *model.Price) er
 func (r *GormPriceRepository) CreateBatch(ctx context.Context, prices []model.Price) error {
 	return r.db.WithContext(ctx).Create(&prices).Error
 }
+
+// DeleteOlderThan は before より古い価格データを最大 limit 件削除し、削除件数を返す
+func (r *GormPriceRepository) DeleteOlderThan(ctx context.Context, before time.Time, limit int) (int64, error) {
+	subQuery := r.db.Model(&model.Price{}).Select("id").Where("ts < ?", before).Limit(limit)
+	result := r.db.WithContext(ctx).Where("id IN (?)", subQuery).Delete(&model.Price{})
+	return result.RowsAffected, result.Error
+}
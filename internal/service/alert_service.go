@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/infrastructure/notifier"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// alertNotifyTimeout は1件の通知送信に許容する時間（再送を含む）
+const alertNotifyTimeout = 2 * time.Minute
+
+// ErrInvalidAlertRule はアラートルールの内容が不正な場合のエラー
+var ErrInvalidAlertRule = errors.New("invalid alert rule")
+
+type AlertRuleResult struct {
+	Rules []model.AlertRule `json:"rules"`
+}
+
+// alertState はルール × 対象ごとの発火状態
+type alertState struct {
+	ruleID    uint
+	since     time.Time // 条件を満たし始めた時刻（満たしていなければゼロ値）
+	fired     bool      // 現在の条件継続中に通知済みか
+	lastFired time.Time
+}
+
+// alertCandidate はルールの評価結果1件
+type alertCandidate struct {
+	subject string
+	value   float64
+	active  bool
+	build   func(alert *notifier.Alert)
+}
+
+// AlertService は価格取得ラウンドごとにアラートルールを評価し、通知を送る
+type AlertService struct {
+	marketRepo    repository.MarketRepository
+	priceRepo     repository.PriceRepository
+	fundingRepo   repository.FundingRateRepository
+	ruleRepo      repository.AlertRuleRepository
+	spreadService *SpreadService
+	notifiers     []notifier.Notifier
+
+	mu     sync.Mutex
+	rules  []model.AlertRule
+	states map[string]*alertState // "ルールID|対象" → 状態
+}
+
+func NewAlertService(
+	marketRepo repository.MarketRepository,
+	priceRepo repository.PriceRepository,
+	fundingRepo repository.FundingRateRepository,
+	ruleRepo repository.AlertRuleRepository,
+	spreadService *SpreadService,
+	notifiers []notifier.Notifier,
+) *AlertService {
+	return &AlertService{
+		marketRepo:    marketRepo,
+		priceRepo:     priceRepo,
+		fundingRepo:   fundingRepo,
+		ruleRepo:      ruleRepo,
+		spreadService: spreadService,
+		notifiers:     notifiers,
+		states:        make(map[string]*alertState),
+	}
+}
+
+// LoadRules は DB からルールを読み込み直す
+func (s *AlertService) LoadRules(ctx context.Context) error {
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *AlertService) ListRules(ctx context.Context) (*AlertRuleResult, error) {
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []model.AlertRule{}
+	}
+	return &AlertRuleResult{Rules: rules}, nil
+}
+
+func (s *AlertService) CreateRule(ctx context.Context, rule *model.AlertRule) error {
+	if err := ValidateAlertRule(rule); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return err
+	}
+	return s.LoadRules(ctx)
+}
+
+func (s *AlertService) DeleteRule(ctx context.Context, id uint) error {
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.LoadRules(ctx)
+}
+
+// ValidateAlertRule はルールの必須項目と値の範囲を検証する
+func ValidateAlertRule(rule *model.AlertRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAlertRule)
+	}
+	switch rule.Type {
+	case notifier.AlertTypeNetSpread, notifier.AlertTypeStale, notifier.AlertTypeFundingDiff:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidAlertRule, rule.Type)
+	}
+	if rule.Threshold < 0 {
+		return fmt.Errorf("%w: threshold must not be negative", ErrInvalidAlertRule)
+	}
+	if rule.ForSeconds < 0 || rule.CooldownSeconds < 0 {
+		return fmt.Errorf("%w: for_seconds and cooldown_seconds must not be negative", ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+// OnPriceRound は価格取得ラウンドの完了ごとに全ルールを評価する
+func (s *AlertService) OnPriceRound(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.rules) == 0 {
+		return
+	}
+
+	now := time.Now()
+	pairsByAsset := make(map[string][]*ArbitrageInfo)
+	seen := make(map[string]bool)
+	failed := make(map[uint]bool)
+
+	for _, rule := range s.rules {
+		if rule.Disabled {
+			continue
+		}
+
+		asset := ruleAsset(rule)
+		var candidates []alertCandidate
+		var err error
+		switch rule.Type {
+		case notifier.AlertTypeNetSpread:
+			pairs, ok := pairsByAsset[asset]
+			if !ok {
+				pairs, err = s.spreadService.CalculatePairSpreads(ctx, asset)
+				if err == nil {
+					pairsByAsset[asset] = pairs
+				}
+			}
+			candidates = netSpreadCandidates(rule, pairs)
+		case notifier.AlertTypeStale:
+			candidates, err = s.staleCandidates(ctx, rule, asset, now)
+		case notifier.AlertTypeFundingDiff:
+			candidates, err = s.fundingDiffCandidates(ctx, rule, asset)
+		}
+		if err != nil {
+			log.Printf("[alert] failed to evaluate rule %s: %v", rule.Name, err)
+			failed[rule.ID] = true
+			continue
+		}
+
+		for _, c := range candidates {
+			seen[alertStateKey(rule.ID, c.subject)] = true
+			s.evaluate(rule, c, now)
+		}
+	}
+
+	s.resetMissing(seen, failed)
+}
+
+// alertStateKey は状態のキー（"ルールID|対象"）を返す
+func alertStateKey(ruleID uint, subject string) string {
+	return strconv.FormatUint(uint64(ruleID), 10) + "|" + subject
+}
+
+// resetMissing は今回のラウンドで評価されなかった対象（ペアが古くなって外れた、ルールが無効になったなど）の
+// 継続をリセットし、再び現れたときに for_seconds から数え直して通知する。
+// 評価に失敗したルールは条件が解消したか分からないため残す。クールダウンのため lastFired は残す
+func (s *AlertService) resetMissing(seen map[string]bool, failed map[uint]bool) {
+	for key, state := range s.states {
+		if seen[key] || failed[state.ruleID] {
+			continue
+		}
+		state.since = time.Time{}
+		state.fired = false
+	}
+}
+
+// evaluate は条件の継続時間とクールダウンを判定し、必要なら通知する。
+// 同じ条件が継続している間は1回だけ通知する
+func (s *AlertService) evaluate(rule model.AlertRule, c alertCandidate, now time.Time) {
+	key := alertStateKey(rule.ID, c.subject)
+	state, ok := s.states[key]
+	if !ok {
+		state = &alertState{ruleID: rule.ID}
+		s.states[key] = state
+	}
+
+	if !c.active {
+		state.since = time.Time{}
+		state.fired = false
+		return
+	}
+
+	if state.since.IsZero() {
+		state.since = now
+	}
+	if state.fired {
+		return
+	}
+	if now.Sub(state.since) < time.Duration(rule.ForSeconds)*time.Second {
+		return
+	}
+	if !state.lastFired.IsZero() && now.Sub(state.lastFired) < time.Duration(rule.CooldownSeconds)*time.Second {
+		return
+	}
+
+	state.fired = true
+	state.lastFired = now
+
+	alert := &notifier.Alert{
+		Version:     notifier.AlertPayloadVersion,
+		RuleName:    rule.Name,
+		RuleType:    rule.Type,
+		Subject:     c.subject,
+		Asset:       ruleAsset(rule),
+		Value:       c.value,
+		Threshold:   rule.Threshold,
+		TriggeredAt: now,
+	}
+	c.build(alert)
+	s.dispatch(alert)
+}
+
+// dispatch は価格取得ジョブを止めないよう非同期で全 Notifier に送信する
+func (s *AlertService) dispatch(alert *notifier.Alert) {
+	log.Printf("[alert] %s: %s", alert.RuleName, alert.Message)
+
+	for _, n := range s.notifiers {
+		go func(n notifier.Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), alertNotifyTimeout)
+			defer cancel()
+
+			if err := n.Notify(ctx, alert); err != nil {
+				log.Printf("[alert] [%s] failed to notify %s: %v", n.Name(), alert.RuleName, err)
+			}
+		}(n)
+	}
+}
+
+// ruleAsset はルールの対象の銘柄を返す
+func ruleAsset(rule model.AlertRule) string {
+	if rule.Asset == "" {
+		return DefaultAsset
+	}
+	return rule.Asset
+}
+
+func netSpreadCandidates(rule model.AlertRule, pairs []*ArbitrageInfo) []alertCandidate {
+	var candidates []alertCandidate
+	for _, pair := range pairs {
+		if rule.BuyExchange != "" && rule.BuyExchange != pair.BuyExchangeKey {
+			continue
+		}
+		if rule.SellExchange != "" && rule.SellExchange != pair.SellExchangeKey {
+			continue
+		}
+
+		candidates = append(candidates, alertCandidate{
+			subject: pair.BuyExchangeKey + ":" + pair.SellExchangeKey,
+			value:   pair.NetPct,
+			active:  pair.NetPct > rule.Threshold,
+			build: func(alert *notifier.Alert) {
+				alert.Message = fmt.Sprintf("%s net spread %.4f%%: buy %s @ %.2f / sell %s @ %.2f",
+					ruleAsset(rule), pair.NetPct, pair.BuyExchange, pair.BuyPrice, pair.SellExchange, pair.SellPrice)
+				alert.Spread = &notifier.SpreadDetails{
+					BuyExchange:  pair.BuyExchangeKey,
+					SellExchange: pair.SellExchangeKey,
+					BuyPrice:     pair.BuyPrice,
+					SellPrice:    pair.SellPrice,
+					NetSpread:    pair.NetSpread,
+					NetPct:       pair.NetPct,
+				}
+			},
+		})
+	}
+	return candidates
+}
+
+func (s *AlertService) staleCandidates(ctx context.Context, rule model.AlertRule, asset string, now time.Time) ([]alertCandidate, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []alertCandidate
+	for _, market := range markets {
+		key := market.Exchange.Key
+		if rule.Exchange != "" && rule.Exchange != key {
+			continue
+		}
+
+		latest, err := s.priceRepo.FindLatestByMarket(ctx, market.ID)
+		if err != nil {
+			continue
+		}
+
+		age := now.Sub(latest.Ts).Seconds()
+		name := market.Exchange.DisplayName
+		lastUpdate := latest.Ts
+		candidates = append(candidates, alertCandidate{
+			subject: key,
+			value:   age,
+			active:  age > rule.Threshold,
+			build: func(alert *notifier.Alert) {
+				alert.Message = fmt.Sprintf("%s %s has not updated for %.0fs", name, asset, age)
+				alert.Stale = &notifier.StaleDetails{
+					Exchange:   key,
+					LastUpdate: lastUpdate,
+					AgeSeconds: age,
+				}
+			},
+		})
+	}
+	return candidates, nil
+}
+
+func (s *AlertService) fundingDiffCandidates(ctx context.Context, rule model.AlertRule, asset string) ([]alertCandidate, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	type fundingQuote struct {
+		key  string
+		name string
+		rate float64
+	}
+	var high, low *fundingQuote
+	for _, market := range markets {
+		if market.Exchange.Reference {
+			continue
+		}
+		latest, err := s.fundingRepo.FindLatestByMarket(ctx, market.ID)
+		if err != nil {
+			continue
+		}
+		q := &fundingQuote{key: market.Exchange.Key, name: market.Exchange.DisplayName, rate: latest.Rate}
+		if high == nil || q.rate > high.rate {
+			high = q
+		}
+		if low == nil || q.rate < low.rate {
+			low = q
+		}
+	}
+	if high == nil || low == nil || high.key == low.key {
+		return nil, nil
+	}
+
+	diffPct := (high.rate - low.rate) * 100
+	return []alertCandidate{{
+		subject: high.key + ":" + low.key,
+		value:   diffPct,
+		active:  diffPct > rule.Threshold,
+		build: func(alert *notifier.Alert) {
+			alert.Message = fmt.Sprintf("%s funding differential %.4f%%/h: %s %.4f%% vs %s %.4f%%",
+				asset, diffPct, high.name, high.rate*100, low.name, low.rate*100)
+			alert.Funding = &notifier.FundingDetails{
+				HighExchange: high.key,
+				LowExchange:  low.key,
+				HighRate:     high.rate,
+				LowRate:      low.rate,
+				DiffPct:      diffPct,
+			}
+		},
+	}}, nil
+}
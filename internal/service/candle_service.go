@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// maxCandles は1回のレスポンスに含める足の上限
+const maxCandles = 5000
+
+// ErrInvalidCandleQuery は足の取得条件が不正な場合のエラー
+var ErrInvalidCandleQuery = errors.New("invalid candle query")
+
+type CandleResult struct {
+	ExchangeKey string         `json:"exchange_key"`
+	Asset       string         `json:"asset"`
+	Interval    string         `json:"interval"`
+	Candles     []model.Candle `json:"candles"`
+}
+
+type CandleService struct {
+	marketRepo repository.MarketRepository
+	candleRepo repository.CandleRepository
+}
+
+func NewCandleService(
+	marketRepo repository.MarketRepository,
+	candleRepo repository.CandleRepository,
+) *CandleService {
+	return &CandleService{
+		marketRepo: marketRepo,
+		candleRepo: candleRepo,
+	}
+}
+
+func (s *CandleService) GetCandles(ctx context.Context, exchangeKey, asset, interval string, from, to time.Time) (*CandleResult, error) {
+	ci, ok := model.FindCandleInterval(interval)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown interval %q", ErrInvalidCandleQuery, interval)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidCandleQuery)
+	}
+	if to.Sub(from)/ci.Duration > maxCandles {
+		return nil, fmt.Errorf("%w: too many candles (max %d), use a larger interval", ErrInvalidCandleQuery, maxCandles)
+	}
+
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		if market.Exchange.Key != exchangeKey {
+			continue
+		}
+
+		candles, err := s.candleRepo.FindByMarketAndTimeRange(ctx, market.ID, ci.Name, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return &CandleResult{
+			ExchangeKey: exchangeKey,
+			Asset:       asset,
+			Interval:    ci.Name,
+			Candles:     candles,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: no %s market on exchange %q", ErrInvalidCandleQuery, asset, exchangeKey)
+}
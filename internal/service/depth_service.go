@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"btc-dex-dashboard/internal/infrastructure/dex"
+	"btc-dex-dashboard/internal/repository"
+)
+
+type DepthResult struct {
+	Asset         string               `json:"asset"`
+	Depth         int                  `json:"depth"`
+	Opportunities []DepthArbitrageInfo `json:"opportunities"`
+}
+
+// DepthArbitrageInfo は板の厚みを考慮したアービトラージ機会。
+// MaxSize は手数料控除後も利益が出る範囲で約定できる最大数量（銘柄の単位）。
+// MixedQuote は買いと売りで価格の単位（USD / USDT）が異なるペア
+type DepthArbitrageInfo struct {
+	BuyExchange  string  `json:"buy_exchange"`
+	SellExchange string  `json:"sell_exchange"`
+	MaxSize      float64 `json:"max_size"`
+	AvgBuyPrice  float64 `json:"avg_buy_price"`
+	AvgSellPrice float64 `json:"avg_sell_price"`
+	GrossProfit  float64 `json:"gross_profit"`
+	TotalFees    float64 `json:"total_fees"`
+	NetProfit    float64 `json:"net_profit"`
+	NetPct       float64 `json:"net_pct"`
+	MixedQuote   bool    `json:"mixed_quote"`
+}
+
+type DepthService struct {
+	clients    []dex.DexClient
+	marketRepo repository.MarketRepository
+}
+
+func NewDepthService(
+	clients []dex.DexClient,
+	marketRepo repository.MarketRepository,
+) *DepthService {
+	return &DepthService{
+		clients:    clients,
+		marketRepo: marketRepo,
+	}
+}
+
+type depthBook struct {
+	name       string
+	takerFee   float64
+	quoteAsset string
+	book       *dex.OrderBook
+}
+
+func (s *DepthService) CalculateDepthArbitrage(ctx context.Context, asset string, depth int) (*DepthResult, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+	}
+
+	type exchangeInfo struct {
+		name       string
+		symbol     string
+		takerFee   float64
+		quoteAsset string
+	}
+	exchangeByKey := make(map[string]exchangeInfo)
+	for _, m := range markets {
+		// 参照取引所（CEX）はアービトラージの対象にしない
+		if m.Exchange.Reference {
+			continue
+		}
+		exchangeByKey[m.Exchange.Key] = exchangeInfo{name: m.Exchange.DisplayName, symbol: m.Symbol, takerFee: m.Exchange.TakerFee, quoteAsset: m.QuoteAsset}
+	}
+
+	// 全 DEX から並行して板を取得
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		books []depthBook
+	)
+	for _, client := range s.clients {
+		info, ok := exchangeByKey[client.Name()]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(c dex.DexClient, info exchangeInfo) {
+			defer wg.Done()
+
+			book, err := c.FetchOrderBook(ctx, info.symbol, depth)
+			if err != nil {
+				log.Printf("[%s %s] failed to fetch order book: %v", c.Name(), info.symbol, err)
+				return
+			}
+
+			mu.Lock()
+			books = append(books, depthBook{name: info.name, takerFee: info.takerFee, quoteAsset: info.quoteAsset, book: book})
+			mu.Unlock()
+		}(client, info)
+	}
+	wg.Wait()
+
+	opportunities := []DepthArbitrageInfo{}
+	for i, buy := range books {
+		for j, sell := range books {
+			if i == j {
+				continue
+			}
+			opportunities = append(opportunities, walkBooks(buy, sell))
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].NetProfit > opportunities[j].NetProfit
+	})
+
+	return &DepthResult{
+		Asset:         asset,
+		Depth:         depth,
+		Opportunities: opportunities,
+	}, nil
+}
+
+// walkBooks は buy の Asks を安い順に買い、sell の Bids を高い順に売りながら、
+// 1単位あたりの手数料控除後損益が正である限り約定数量を積み上げる
+func walkBooks(buy, sell depthBook) DepthArbitrageInfo {
+	info := DepthArbitrageInfo{
+		BuyExchange:  buy.name,
+		SellExchange: sell.name,
+		MixedQuote:   buy.quoteAsset != sell.quoteAsset,
+	}
+
+	asks := buy.book.Asks
+	bids := sell.book.Bids
+
+	var cost, proceeds float64
+	var i, j int
+	var askRemain, bidRemain float64
+	if len(asks) > 0 {
+		askRemain = asks[0].Size
+	}
+	if len(bids) > 0 {
+		bidRemain = bids[0].Size
+	}
+
+	for i < len(asks) && j < len(bids) {
+		ask := asks[i].Price
+		bid := bids[j].Price
+
+		unitNet := bid*(1-sell.takerFee) - ask*(1+buy.takerFee)
+		if unitNet <= 0 {
+			break
+		}
+
+		qty := askRemain
+		if bidRemain < qty {
+			qty = bidRemain
+		}
+
+		info.MaxSize += qty
+		cost += qty * ask
+		proceeds += qty * bid
+		info.TotalFees += qty * (ask*buy.takerFee + bid*sell.takerFee)
+
+		askRemain -= qty
+		bidRemain -= qty
+		if askRemain <= 0 {
+			i++
+			if i < len(asks) {
+				askRemain = asks[i].Size
+			}
+		}
+		if bidRemain <= 0 {
+			j++
+			if j < len(bids) {
+				bidRemain = bids[j].Size
+			}
+		}
+	}
+
+	if info.MaxSize > 0 {
+		info.AvgBuyPrice = cost / info.MaxSize
+		info.AvgSellPrice = proceeds / info.MaxSize
+		info.GrossProfit = proceeds - cost
+		info.NetProfit = info.GrossProfit - info.TotalFees
+		info.NetPct = (info.NetProfit / cost) * 100
+	}
+
+	return info
+}
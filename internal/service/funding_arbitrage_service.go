@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// hoursPerYear は Funding の年率換算に使う1年の時間数
+const hoursPerYear = 24 * 365
+
+type FundingArbitrageResult struct {
+	Asset          string                 `json:"asset"`
+	HoldingHours   float64                `json:"holding_hours"`
+	Pairs          []FundingArbitrageInfo `json:"pairs"`           // 期待損益の高い順
+	StaleExchanges []string               `json:"stale_exchanges"` // Funding Rate が古く計算から除外した取引所のキー
+}
+
+// FundingArbitrageInfo は Funding の低い取引所でロング、高い取引所でショートした場合の損益の見込み。
+// 率（%）はいずれもロング側の約定価格に対する割合
+type FundingArbitrageInfo struct {
+	LongExchange     string   `json:"long_exchange"`
+	ShortExchange    string   `json:"short_exchange"`
+	LongExchangeKey  string   `json:"long_exchange_key"`
+	ShortExchangeKey string   `json:"short_exchange_key"`
+	LongRate         float64  `json:"long_rate"`  // 1時間あたり
+	ShortRate        float64  `json:"short_rate"` // 1時間あたり
+	RateDiff         float64  `json:"rate_diff"`  // ショート側 − ロング側（1時間あたりの受け取り）
+	AnnualizedPct    float64  `json:"annualized_pct"`
+	CarryPct         float64  `json:"carry_pct"`              // 保有期間中に受け取る Funding
+	SpreadPct        float64  `json:"spread_pct"`             // 建てる時の価格差（ショート側の bid − ロング側の ask）。負ならコスト
+	RoundTripFeePct  float64  `json:"round_trip_fee_pct"`     // 両レッグの建て・決済のテイカー手数料
+	NetPct           float64  `json:"net_pct"`                // carry_pct + spread_pct − round_trip_fee_pct
+	BreakEvenHours   *float64 `json:"break_even_hours"`       // 手数料と価格差を Funding で回収するまでの時間。回収できなければ null
+	LongRateAgeSec   float64  `json:"long_rate_age_seconds"`  // ロング側の Funding Rate を取得してからの経過秒数
+	ShortRateAgeSec  float64  `json:"short_rate_age_seconds"` // ショート側の Funding Rate を取得してからの経過秒数
+	MixedQuote       bool     `json:"mixed_quote"`            // ロングとショートで価格の単位（USD / USDT）が異なる
+}
+
+// FundingArbitrageService は取引所間の Funding Rate の差を使ったキャリートレードの損益を計算する。
+// staleAfter > 0 なら取得から staleAfter 以上経った Funding Rate を古いとみなし、計算から除外する
+type FundingArbitrageService struct {
+	fundingService *FundingService
+	spreadService  *SpreadService
+	staleAfter     time.Duration
+}
+
+func NewFundingArbitrageService(fundingService *FundingService, spreadService *SpreadService, staleAfter time.Duration) *FundingArbitrageService {
+	return &FundingArbitrageService{
+		fundingService: fundingService,
+		spreadService:  spreadService,
+		staleAfter:     staleAfter,
+	}
+}
+
+// Calculate は Funding と最新の気配値がそろっている全ての DEX ペアについて、
+// holdingHours 保有した場合の損益を計算する。価格差は決済時に解消し、決済の手数料は建てる時と同じと仮定する。
+// 古い Funding Rate の取引所は除外し、StaleExchanges に返す
+func (s *FundingArbitrageService) Calculate(ctx context.Context, asset string, holdingHours float64) (*FundingArbitrageResult, error) {
+	funding, err := s.fundingService.GetLatestRates(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	stale := []string{}
+	rates := make(map[string]fundingRateAge, len(funding.Rates))
+	for _, r := range funding.Rates {
+		age := now.Sub(r.Ts)
+		if s.staleAfter > 0 && age >= s.staleAfter {
+			stale = append(stale, r.ExchangeKey)
+			continue
+		}
+		rates[r.ExchangeKey] = fundingRateAge{rate: r.Rate, age: age}
+	}
+
+	spreads, err := s.spreadService.CalculatePairSpreads(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []FundingArbitrageInfo{}
+	for _, spread := range spreads {
+		long, ok := rates[spread.BuyExchangeKey]
+		if !ok {
+			continue
+		}
+		short, ok := rates[spread.SellExchangeKey]
+		if !ok {
+			continue
+		}
+		// Funding の低い側でロングする向きのみ（同率なら1ペアにつき片方の向き）
+		if long.rate > short.rate || (long.rate == short.rate && spread.BuyExchangeKey > spread.SellExchangeKey) {
+			continue
+		}
+		info := newFundingArbitrageInfo(spread, long.rate, short.rate, holdingHours)
+		info.LongRateAgeSec = long.age.Seconds()
+		info.ShortRateAgeSec = short.age.Seconds()
+		pairs = append(pairs, info)
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].NetPct > pairs[j].NetPct
+	})
+
+	return &FundingArbitrageResult{Asset: asset, HoldingHours: holdingHours, Pairs: pairs, StaleExchanges: stale}, nil
+}
+
+// fundingRateAge は1時間あたりの Funding Rate と取得からの経過時間
+type fundingRateAge struct {
+	rate float64
+	age  time.Duration
+}
+
+func newFundingArbitrageInfo(spread *ArbitrageInfo, longRate, shortRate, holdingHours float64) FundingArbitrageInfo {
+	diff := shortRate - longRate
+	spreadPct := spread.SpreadPct
+	// ArbitrageInfo の手数料は建てる時の両レッグ分。決済時も同額とみなす
+	roundTripFeePct := 2 * spread.TotalFees / spread.BuyPrice * 100
+	carryPct := diff * holdingHours * 100
+
+	info := FundingArbitrageInfo{
+		LongExchange:     spread.BuyExchange,
+		ShortExchange:    spread.SellExchange,
+		LongExchangeKey:  spread.BuyExchangeKey,
+		ShortExchangeKey: spread.SellExchangeKey,
+		LongRate:         longRate,
+		ShortRate:        shortRate,
+		RateDiff:         diff,
+		AnnualizedPct:    diff * hoursPerYear * 100,
+		CarryPct:         carryPct,
+		SpreadPct:        spreadPct,
+		RoundTripFeePct:  roundTripFeePct,
+		NetPct:           carryPct + spreadPct - roundTripFeePct,
+		MixedQuote:       spread.MixedQuote,
+	}
+
+	cost := roundTripFeePct - spreadPct
+	switch {
+	case cost <= 0:
+		zero := 0.0
+		info.BreakEvenHours = &zero
+	case diff > 0:
+		hours := cost / (diff * 100)
+		info.BreakEvenHours = &hours
+	}
+	return info
+}
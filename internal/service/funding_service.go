@@ -2,19 +2,23 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"btc-dex-dashboard/internal/repository"
 )
 
 type FundingResult struct {
+	Asset string        `json:"asset"`
 	Rates []FundingInfo `json:"rates"`
 }
 
 type FundingInfo struct {
-	ExchangeKey  string  `json:"exchange_key"`
-	ExchangeName string  `json:"exchange_name"`
-	Rate         float64 `json:"rate"`
-	RatePct      float64 `json:"rate_pct"`
+	ExchangeKey  string    `json:"exchange_key"`
+	ExchangeName string    `json:"exchange_name"`
+	Rate         float64   `json:"rate"`
+	RatePct      float64   `json:"rate_pct"`
+	Ts           time.Time `json:"ts"` // 取得した時刻
 }
 
 type FundingService struct {
@@ -32,15 +36,22 @@ func NewFundingService(
 	}
 }
 
-func (s *FundingService) GetLatestRates(ctx context.Context) (*FundingResult, error) {
-	markets, err := s.marketRepo.FindAll(ctx)
+func (s *FundingService) GetLatestRates(ctx context.Context, asset string) (*FundingResult, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+	}
 
 	var rates []FundingInfo
 
 	for _, market := range markets {
+		// 参照取引所（CEX）の Funding Rate は取得しない
+		if market.Exchange.Reference {
+			continue
+		}
 		latestRate, err := s.fundingRepo.FindLatestByMarket(ctx, market.ID)
 		if err != nil {
 			continue
@@ -51,9 +62,10 @@ func (s *FundingService) GetLatestRates(ctx context.Context) (*FundingResult, er
 			ExchangeName: market.Exchange.DisplayName,
 			Rate:         latestRate.Rate,
 			RatePct:      latestRate.Rate * 100,
+			Ts:           latestRate.Ts,
 		}
 		rates = append(rates, info)
 	}
 
-	return &FundingResult{Rates: rates}, nil
+	return &FundingResult{Asset: asset, Rates: rates}, nil
 }
\ No newline at end of file
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/infrastructure/dex"
+	"btc-dex-dashboard/internal/repository"
+)
+
+// latencySamples はレイテンシのパーセンタイル計算に使う直近の件数
+const latencySamples = 200
+
+// ExchangeStatus は取引所ごとの価格取得の状態。Markets はマーケットごとの状態で、
+// 取引所の項目はそれをまとめたもの（Healthy はいずれかのマーケットが健全、LastError は直近の失敗）
+type ExchangeStatus struct {
+	ExchangeKey         string              `json:"exchange_key"`
+	ExchangeName        string              `json:"exchange_name"`
+	Healthy             bool                `json:"healthy"`
+	LastSuccess         *time.Time          `json:"last_success"`
+	LastFailure         *time.Time          `json:"last_failure"`
+	LastError           string              `json:"last_error"`
+	ConsecutiveFailures int                 `json:"consecutive_failures"` // 全マーケットが失敗したラウンドの連続数
+	LatencyP50Ms        float64             `json:"latency_p50_ms"`
+	LatencyP99Ms        float64             `json:"latency_p99_ms"`
+	RateLimit           *dex.RateLimitStats `json:"rate_limit"` // レート制限を設定していない取引所は nil
+	Markets             []MarketStatus      `json:"markets"`
+}
+
+// MarketStatus はマーケットごとの価格取得の状態
+type MarketStatus struct {
+	Symbol              string     `json:"symbol"`
+	Healthy             bool       `json:"healthy"` // 直近 StaleAfter 以内に成功し、連続失敗が MaxConsecutiveFailures 未満。false ならアービトラージの計算から除外
+	LastSuccess         *time.Time `json:"last_success"`
+	LastFailure         *time.Time `json:"last_failure"`
+	LastError           string     `json:"last_error"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+type exchangeHealth struct {
+	markets   map[string]*marketHealth // シンボル → 状態
+	latencies []time.Duration          // リングバッファ
+	next      int
+}
+
+type marketHealth struct {
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           string
+	consecutiveFailures int
+}
+
+// HealthService は PriceFetcher から取得結果を受け取り、マーケットごとの状態を保持する。
+// staleAfter 以内に成功していないか、maxFailures 回続けて失敗したマーケットを不健全とみなす
+type HealthService struct {
+	exchangeRepo repository.ExchangeRepository
+	staleAfter   time.Duration
+	maxFailures  int
+	limiters     map[string]*dex.RateLimiter // 取引所キー → レート制限
+
+	mu        sync.Mutex
+	exchanges map[string]*exchangeHealth
+}
+
+func NewHealthService(
+	exchangeRepo repository.ExchangeRepository,
+	staleAfter time.Duration,
+	maxFailures int,
+	limiters map[string]*dex.RateLimiter,
+) *HealthService {
+	return &HealthService{
+		exchangeRepo: exchangeRepo,
+		staleAfter:   staleAfter,
+		maxFailures:  maxFailures,
+		limiters:     limiters,
+		exchanges:    make(map[string]*exchangeHealth),
+	}
+}
+
+// RecordSuccess はマーケットの価格取得の成功を記録する。
+// latency は REST の往復時間で、WebSocket のキャッシュから返した場合は0（レイテンシに含めない）
+func (s *HealthService) RecordSuccess(exchangeKey, symbol string, latency time.Duration, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.get(exchangeKey)
+	m := h.market(symbol)
+	m.lastSuccess = at
+	m.consecutiveFailures = 0
+	if latency <= 0 {
+		return
+	}
+	if len(h.latencies) < latencySamples {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.next] = latency
+	}
+	h.next = (h.next + 1) % latencySamples
+}
+
+// RecordFailure はマーケットの価格取得の失敗を記録する
+func (s *HealthService) RecordFailure(exchangeKey, symbol string, err error, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.get(exchangeKey).market(symbol)
+	m.lastFailure = at
+	m.lastError = err.Error()
+	m.consecutiveFailures++
+}
+
+// MarketHealthy はマーケットの気配値をアービトラージの計算に使えるかを返す。一度も取得していなければ false
+func (s *HealthService) MarketHealthy(exchangeKey, symbol string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.exchanges[exchangeKey]
+	if !ok {
+		return false
+	}
+	m, ok := h.markets[symbol]
+	return ok && s.healthy(m, time.Now())
+}
+
+func (s *HealthService) healthy(m *marketHealth, now time.Time) bool {
+	if m.lastSuccess.IsZero() || now.Sub(m.lastSuccess) > s.staleAfter {
+		return false
+	}
+	return s.maxFailures <= 0 || m.consecutiveFailures < s.maxFailures
+}
+
+func (s *HealthService) get(exchangeKey string) *exchangeHealth {
+	h, ok := s.exchanges[exchangeKey]
+	if !ok {
+		h = &exchangeHealth{markets: make(map[string]*marketHealth)}
+		s.exchanges[exchangeKey] = h
+	}
+	return h
+}
+
+func (h *exchangeHealth) market(symbol string) *marketHealth {
+	m, ok := h.markets[symbol]
+	if !ok {
+		m = &marketHealth{}
+		h.markets[symbol] = m
+	}
+	return m
+}
+
+// Status は全取引所の状態を返す。一度も取得していない取引所は Healthy = false
+func (s *HealthService) Status(ctx context.Context) ([]ExchangeStatus, error) {
+	exchanges, err := s.exchangeRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]ExchangeStatus, 0, len(exchanges))
+	for _, ex := range exchanges {
+		st := ExchangeStatus{
+			ExchangeKey:  ex.Key,
+			ExchangeName: ex.DisplayName,
+			Markets:      []MarketStatus{},
+		}
+		if h, ok := s.exchanges[ex.Key]; ok {
+			s.fillStatus(&st, h, now)
+		}
+		if l, ok := s.limiters[ex.Key]; ok {
+			stats := l.Stats()
+			st.RateLimit = &stats
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// fillStatus はマーケットごとの状態と、それをまとめた取引所の状態を st に設定する
+func (s *HealthService) fillStatus(st *ExchangeStatus, h *exchangeHealth, now time.Time) {
+	symbols := make([]string, 0, len(h.markets))
+	for symbol := range h.markets {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var lastSuccess, lastFailure time.Time
+	st.ConsecutiveFailures = -1
+	for _, symbol := range symbols {
+		m := h.markets[symbol]
+		ms := MarketStatus{
+			Symbol:              symbol,
+			Healthy:             s.healthy(m, now),
+			LastError:           m.lastError,
+			ConsecutiveFailures: m.consecutiveFailures,
+		}
+		if !m.lastSuccess.IsZero() {
+			t := m.lastSuccess
+			ms.LastSuccess = &t
+			if t.After(lastSuccess) {
+				lastSuccess = t
+			}
+		}
+		if !m.lastFailure.IsZero() {
+			t := m.lastFailure
+			ms.LastFailure = &t
+			if t.After(lastFailure) {
+				lastFailure = t
+				st.LastError = symbol + ": " + m.lastError
+			}
+		}
+		st.Healthy = st.Healthy || ms.Healthy
+		if st.ConsecutiveFailures < 0 || m.consecutiveFailures < st.ConsecutiveFailures {
+			st.ConsecutiveFailures = m.consecutiveFailures
+		}
+		st.Markets = append(st.Markets, ms)
+	}
+	if st.ConsecutiveFailures < 0 {
+		st.ConsecutiveFailures = 0
+	}
+	if !lastSuccess.IsZero() {
+		st.LastSuccess = &lastSuccess
+	}
+	if !lastFailure.IsZero() {
+		st.LastFailure = &lastFailure
+	}
+	st.LatencyP50Ms, st.LatencyP99Ms = latencyPercentiles(h.latencies)
+}
+
+// latencyPercentiles は p50 / p99 をミリ秒で返す（最近傍順位法）
+func latencyPercentiles(samples []time.Duration) (p50, p99 float64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) float64 {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return float64(sorted[idx]) / float64(time.Millisecond)
+	}
+	return percentile(0.50), percentile(0.99)
+}
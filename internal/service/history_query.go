@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultHistoryWindow は履歴のデフォルト期間
+	defaultHistoryWindow = 15 * time.Minute
+	// defaultHistoryPoints はバケット幅を省略した場合の目標ポイント数
+	defaultHistoryPoints = 180
+	// maxHistoryWindow は指定できる期間の上限
+	maxHistoryWindow = 7 * 24 * time.Hour
+	// maxHistoryBuckets は1回のレスポンスに含めるバケット数の上限
+	maxHistoryBuckets = 2000
+	// retentionGrace は保持期間の境界の猶予。window=48h のような保持期間ちょうどの指定を処理中の時間経過で弾かない
+	retentionGrace = time.Minute
+)
+
+// ErrInvalidHistoryQuery は履歴の期間・バケット指定が不正な場合のエラー
+var ErrInvalidHistoryQuery = errors.New("invalid history query")
+
+// HistoryQuery は履歴の集計期間とバケット幅
+type HistoryQuery struct {
+	From   time.Time
+	To     time.Time
+	Bucket time.Duration
+}
+
+// DefaultHistoryQuery は now までの直近15分を約180ポイントで集計するクエリを返す
+func DefaultHistoryQuery(now time.Time) HistoryQuery {
+	return NewHistoryQuery(now.Add(-defaultHistoryWindow), now, 0)
+}
+
+// NewHistoryQuery は bucket が0（省略）の場合に期間から自動でバケット幅を決める。負の値はそのまま Validate で弾く
+func NewHistoryQuery(from, to time.Time, bucket time.Duration) HistoryQuery {
+	if bucket == 0 {
+		bucket = (to.Sub(from) / defaultHistoryPoints).Truncate(time.Second)
+		if bucket < time.Second {
+			bucket = time.Second
+		}
+	}
+	return HistoryQuery{From: from, To: to, Bucket: bucket}
+}
+
+// MaxHistoryWindow は生の価格データの保持期間 retention（0 は無期限）のもとで指定できる期間の上限を返す
+func MaxHistoryWindow(retention time.Duration) time.Duration {
+	if retention > 0 && retention < maxHistoryWindow {
+		return retention
+	}
+	return maxHistoryWindow
+}
+
+// ValidateRawRetention は生の価格データの保持期間が履歴の既定の期間を満たすかを検証する（起動時）
+func ValidateRawRetention(retention time.Duration) error {
+	if retention < 0 {
+		return fmt.Errorf("raw price retention must not be negative")
+	}
+	if retention > 0 && retention < defaultHistoryWindow {
+		return fmt.Errorf("raw price retention %v is shorter than the default history window %v", retention, defaultHistoryWindow)
+	}
+	return nil
+}
+
+// Validate は期間とバケット幅の妥当性を検証する
+func (q HistoryQuery) Validate() error {
+	window := q.To.Sub(q.From)
+	if window <= 0 {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidHistoryQuery)
+	}
+	if window > maxHistoryWindow {
+		return fmt.Errorf("%w: range must not exceed %v", ErrInvalidHistoryQuery, maxHistoryWindow)
+	}
+	if q.Bucket < time.Second {
+		return fmt.Errorf("%w: bucket must be at least 1s", ErrInvalidHistoryQuery)
+	}
+	if int64(window/q.Bucket) > maxHistoryBuckets {
+		return fmt.Errorf("%w: too many buckets (max %d), use a larger bucket", ErrInvalidHistoryQuery, maxHistoryBuckets)
+	}
+	return nil
+}
+
+// ValidateRetention は期間が生の価格データの保持期間 retention（0 は無期限）に収まるかを検証する。
+// 保持期間より前の価格は削除済みのため、欠けた履歴を返さずにエラーにする
+func (q HistoryQuery) ValidateRetention(now time.Time, retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+	if q.From.Before(now.Add(-retention - retentionGrace)) {
+		return fmt.Errorf("%w: from must be within the last %v (raw price retention)", ErrInvalidHistoryQuery, retention)
+	}
+	return nil
+}
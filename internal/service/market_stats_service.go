@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"btc-dex-dashboard/internal/repository"
+)
+
+type MarketStatsResult struct {
+	Asset   string            `json:"asset"`
+	Markets []MarketStatsInfo `json:"markets"`
+}
+
+// MarketStatsInfo はマーケットの最新の統計。取引所が返さない項目は null
+type MarketStatsInfo struct {
+	ExchangeKey     string   `json:"exchange_key"`
+	ExchangeName    string   `json:"exchange_name"`
+	Symbol          string   `json:"symbol"`
+	Reference       bool     `json:"reference"` // 公正価格の参照取引所（CEX）
+	Timestamp       string   `json:"timestamp"`
+	MarkPrice       *float64 `json:"mark_price"`
+	IndexPrice      *float64 `json:"index_price"`
+	BasisPct        *float64 `json:"basis_pct"` // マーク価格のインデックス価格に対する乖離率（%）
+	OpenInterest    *float64 `json:"open_interest"`
+	OpenInterestUSD *float64 `json:"open_interest_usd"` // 建玉 × マーク価格（なければインデックス価格、最新の気配値の仲値）
+	Volume24h       *float64 `json:"volume_24h"`
+}
+
+type MarketStatsService struct {
+	marketRepo repository.MarketRepository
+	statsRepo  repository.MarketStatsRepository
+	priceRepo  repository.PriceRepository
+}
+
+func NewMarketStatsService(
+	marketRepo repository.MarketRepository,
+	statsRepo repository.MarketStatsRepository,
+	priceRepo repository.PriceRepository,
+) *MarketStatsService {
+	return &MarketStatsService{
+		marketRepo: marketRepo,
+		statsRepo:  statsRepo,
+		priceRepo:  priceRepo,
+	}
+}
+
+func (s *MarketStatsService) GetLatestStats(ctx context.Context, asset string) (*MarketStatsResult, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+	}
+
+	stats := []MarketStatsInfo{}
+	for _, market := range markets {
+		// 統計に対応していない取引所・未取得のマーケットは含めない
+		latest, err := s.statsRepo.FindLatestByMarket(ctx, market.ID)
+		if err != nil {
+			continue
+		}
+
+		info := MarketStatsInfo{
+			ExchangeKey:  market.Exchange.Key,
+			ExchangeName: market.Exchange.DisplayName,
+			Symbol:       market.Symbol,
+			Reference:    market.Exchange.Reference,
+			Timestamp:    latest.Ts.Format(time.RFC3339),
+			MarkPrice:    latest.MarkPrice,
+			IndexPrice:   latest.IndexPrice,
+			OpenInterest: latest.OpenInterest,
+			Volume24h:    latest.Volume24h,
+		}
+		if latest.MarkPrice != nil && latest.IndexPrice != nil && *latest.IndexPrice != 0 {
+			basis := (*latest.MarkPrice - *latest.IndexPrice) / *latest.IndexPrice * 100
+			info.BasisPct = &basis
+		}
+		if latest.OpenInterest != nil {
+			if price := s.referencePrice(ctx, market.ID, latest.MarkPrice, latest.IndexPrice); price > 0 {
+				notional := *latest.OpenInterest * price
+				info.OpenInterestUSD = &notional
+			}
+		}
+		stats = append(stats, info)
+	}
+
+	return &MarketStatsResult{Asset: asset, Markets: stats}, nil
+}
+
+// referencePrice は建玉の評価に使う価格。マーク価格、インデックス価格、最新の気配値の仲値の順に使う
+func (s *MarketStatsService) referencePrice(ctx context.Context, marketID uint, mark, index *float64) float64 {
+	if mark != nil {
+		return *mark
+	}
+	if index != nil {
+		return *index
+	}
+	price, err := s.priceRepo.FindLatestByMarket(ctx, marketID)
+	if err != nil {
+		return 0
+	}
+	return (price.Bid + price.Ask) / 2
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"btc-dex-dashboard/internal/domain/model"
+	"btc-dex-dashboard/internal/repository"
+)
+
+type OpportunityResult struct {
+	Opportunities []model.Opportunity `json:"opportunities"`
+}
+
+// OpportunityService は価格取得ラウンドごとに銘柄ごとの取引所ペアの純スプレッドを監視し、
+// 閾値を超えている間をアービトラージ機会として記録する
+type OpportunityService struct {
+	spreadService *SpreadService
+	oppRepo       repository.OpportunityRepository
+	assets        []string
+	thresholdPct  float64 // 純スプレッド（%）がこの値を超えたら機会とみなす
+
+	mu   sync.Mutex
+	open map[string]*model.Opportunity // "銘柄:買い:売り" → 継続中の機会
+}
+
+func NewOpportunityService(
+	spreadService *SpreadService,
+	oppRepo repository.OpportunityRepository,
+	assets []string,
+	thresholdPct float64,
+) *OpportunityService {
+	return &OpportunityService{
+		spreadService: spreadService,
+		oppRepo:       oppRepo,
+		assets:        assets,
+		thresholdPct:  thresholdPct,
+		open:          make(map[string]*model.Opportunity),
+	}
+}
+
+func opportunityKey(asset, buy, sell string) string {
+	return asset + ":" + buy + ":" + sell
+}
+
+// LoadOpen は再起動前から継続中の機会を読み込む
+func (s *OpportunityService) LoadOpen(ctx context.Context) error {
+	opps, err := s.oppRepo.FindOpen(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range opps {
+		opp := opps[i]
+		s.open[opportunityKey(opp.Asset, opp.BuyExchange, opp.SellExchange)] = &opp
+	}
+	return nil
+}
+
+// OnPriceRound は価格取得ラウンドの完了ごとに機会の開始・更新・終了を判定する
+func (s *OpportunityService) OnPriceRound(ctx context.Context) {
+	now := time.Now()
+	seen := make(map[string]bool)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, asset := range s.assets {
+		pairs, err := s.spreadService.CalculatePairSpreads(ctx, asset)
+		if err != nil {
+			log.Printf("[opportunity] failed to calculate pair spreads for %s: %v", asset, err)
+			continue
+		}
+		s.track(ctx, asset, pairs, seen, now)
+	}
+
+	// 価格が取得できなくなったペアも終了扱いにする
+	for key, opp := range s.open {
+		if !seen[key] {
+			s.close(ctx, key, opp, now)
+		}
+	}
+}
+
+// track は1銘柄分のペアについて機会の開始・更新・終了を判定する
+func (s *OpportunityService) track(ctx context.Context, asset string, pairs []*ArbitrageInfo, seen map[string]bool, now time.Time) {
+	for _, pair := range pairs {
+		key := opportunityKey(asset, pair.BuyExchangeKey, pair.SellExchangeKey)
+		seen[key] = true
+		opp, isOpen := s.open[key]
+
+		switch {
+		case pair.NetPct > s.thresholdPct && !isOpen:
+			opp = &model.Opportunity{
+				Asset:         asset,
+				BuyExchange:   pair.BuyExchangeKey,
+				SellExchange:  pair.SellExchangeKey,
+				StartedAt:     now,
+				PeakNetSpread: pair.NetSpread,
+				PeakNetPct:    pair.NetPct,
+				PeakAt:        now,
+			}
+			if err := s.oppRepo.Create(ctx, opp); err != nil {
+				log.Printf("[opportunity] failed to create %s: %v", key, err)
+				continue
+			}
+			s.open[key] = opp
+			log.Printf("[opportunity] opened %s: net=%.4f%%", key, pair.NetPct)
+
+		case pair.NetPct > s.thresholdPct && isOpen:
+			if pair.NetPct <= opp.PeakNetPct {
+				continue
+			}
+			opp.PeakNetSpread = pair.NetSpread
+			opp.PeakNetPct = pair.NetPct
+			opp.PeakAt = now
+			if err := s.oppRepo.Update(ctx, opp); err != nil {
+				log.Printf("[opportunity] failed to update %s: %v", key, err)
+			}
+
+		case isOpen:
+			s.close(ctx, key, opp, now)
+		}
+	}
+}
+
+func (s *OpportunityService) close(ctx context.Context, key string, opp *model.Opportunity, now time.Time) {
+	opp.EndedAt = &now
+	opp.DurationSeconds = now.Sub(opp.StartedAt).Seconds()
+	if err := s.oppRepo.Update(ctx, opp); err != nil {
+		log.Printf("[opportunity] failed to close %s: %v", key, err)
+		return
+	}
+	delete(s.open, key)
+	log.Printf("[opportunity] closed %s: duration=%.1fs, peak=%.4f%%", key, opp.DurationSeconds, opp.PeakNetPct)
+}
+
+func (s *OpportunityService) List(ctx context.Context, filter repository.OpportunityFilter) (*OpportunityResult, error) {
+	opps, err := s.oppRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if opps == nil {
+		opps = []model.Opportunity{}
+	}
+	return &OpportunityResult{Opportunities: opps}, nil
+}
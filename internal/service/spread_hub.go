@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// SpreadHub は銘柄ごとに SpreadResult を1回だけ計算し、購読中の全クライアントへ配信する
+type SpreadHub struct {
+	spreadService *SpreadService
+
+	mu          sync.RWMutex
+	latest      map[string]*SpreadResult      // 銘柄 → 直近のスナップショット
+	subscribers map[chan *SpreadResult]string // channel → 購読中の銘柄
+}
+
+func NewSpreadHub(spreadService *SpreadService) *SpreadHub {
+	return &SpreadHub{
+		spreadService: spreadService,
+		latest:        make(map[string]*SpreadResult),
+		subscribers:   make(map[chan *SpreadResult]string),
+	}
+}
+
+// OnPriceRound は価格取得ラウンドの完了ごとに呼ばれ、購読者のいる銘柄の最新のスナップショットを配信する
+func (h *SpreadHub) OnPriceRound(ctx context.Context) {
+	h.mu.RLock()
+	assets := make(map[string]bool)
+	for _, asset := range h.subscribers {
+		assets[asset] = true
+	}
+	h.mu.RUnlock()
+
+	// 購読者がいない銘柄は計算しない
+	results := make(map[string]*SpreadResult, len(assets))
+	for asset := range assets {
+		result, err := h.spreadService.CalculateSpread(ctx, asset, DefaultHistoryQuery(time.Now()))
+		if err != nil {
+			log.Printf("[stream] failed to calculate spread for %s: %v", asset, err)
+			continue
+		}
+		results[asset] = result
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// 計算中に購読者がいなくなった銘柄はキャッシュしない（次の購読者に古いスナップショットを渡さないため）
+	for ch, asset := range h.subscribers {
+		result, ok := results[asset]
+		if !ok {
+			continue
+		}
+		h.latest[asset] = result
+		// 受信が追いつかないクライアントは古いスナップショットを捨てて最新のみ保持する
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- result
+	}
+}
+
+// Subscribe は asset の配信用の channel と購読解除関数を返す。
+// 直近のスナップショットがあれば即座に channel へ入る。追跡していない銘柄は ErrUnknownAsset
+func (h *SpreadHub) Subscribe(ctx context.Context, asset string) (<-chan *SpreadResult, func(), error) {
+	if _, err := h.spreadService.findMarkets(ctx, asset); err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan *SpreadResult, 1)
+
+	h.mu.Lock()
+	h.subscribers[ch] = asset
+	if latest, ok := h.latest[asset]; ok {
+		ch <- latest
+	}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		delete(h.subscribers, ch)
+		// 購読者がいない間は更新されないため、古いスナップショットを残さない
+		for _, a := range h.subscribers {
+			if a == asset {
+				h.mu.Unlock()
+				return
+			}
+		}
+		delete(h.latest, asset)
+		h.mu.Unlock()
+	}
+	return ch, unsubscribe, nil
+}
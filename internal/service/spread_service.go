@@ -2,18 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"time"
 
+	"btc-dex-dashboard/internal/domain/model"
 	"btc-dex-dashboard/internal/repository"
 )
 
+// DefaultAsset は銘柄を指定しなかった場合の対象
+const DefaultAsset = "BTC"
+
+// ErrUnknownAsset は追跡していない銘柄を指定した場合のエラー
+var ErrUnknownAsset = errors.New("unknown asset")
+
+// SpreadResult は銘柄のスプレッド。Prices は DEX、References は公正価格の参照取引所（CEX）の気配値。
+// FairValues は価格の単位ごとの公正価格、FairValue はそのうち参照取引所が最も多い単位のもの
 type SpreadResult struct {
-	Prices          []PriceInfo    `json:"prices"`
-	BuyOpportunity  *ArbitrageInfo `json:"buy_opportunity"`
-	SellOpportunity *ArbitrageInfo `json:"sell_opportunity"`
-	History         []HistoryPoint `json:"history"`
-	Stats           *SpreadStats   `json:"stats"`
+	Asset           string           `json:"asset"`
+	Prices          []PriceInfo      `json:"prices"`
+	References      []PriceInfo      `json:"references"`
+	FairValue       *FairValueInfo   `json:"fair_value"`
+	FairValues      []*FairValueInfo `json:"fair_values"`
+	BuyOpportunity  *ArbitrageInfo   `json:"buy_opportunity"`
+	SellOpportunity *ArbitrageInfo   `json:"sell_opportunity"`
+	History         []HistoryPoint   `json:"history"`
+	Stats           *SpreadStats     `json:"stats"`
 }
 
 type PriceInfo struct {
@@ -22,15 +37,33 @@ type PriceInfo struct {
 	Bid          float64 `json:"bid"`
 	Ask          float64 `json:"ask"`
 	MidPrice     float64 `json:"mid_price"`
+	QuoteAsset   string  `json:"quote_asset"` // 価格の単位（USD / USDT）
+	Stale        bool    `json:"stale"`       // StaleAfter 以上更新がないか取得が失敗し続けている。アービトラージの計算からは除外
+	// PremiumPct は同じ単位の公正価格に対する仲値の乖離率（%、正なら割高）。同じ単位の公正価格がなければ null
+	PremiumPct *float64 `json:"premium_pct"`
 }
 
+// FairValueInfo は価格の単位が QuoteAsset の参照取引所の仲値の中央値による公正価格
+type FairValueInfo struct {
+	Price      float64  `json:"price"`
+	QuoteAsset string   `json:"quote_asset"`
+	Sources    []string `json:"sources"` // 算出に使った参照取引所のキー
+}
+
+// HistoryPoint は履歴の1バケット。Prices は DEX の取引所キー → バケット内の仲値の平均、
+// Min / Max はバケット内の仲値の最小・最大（バケット内にデータがあった取引所のみ）。
+// FairValue は SpreadResult.FairValue と同じ単位の参照取引所の仲値の中央値、
+// Premiums は DEX の取引所キー → 同じ単位の公正価格に対する乖離率（%）で、
+// 同じ単位の参照取引所の価格がないバケット・取引所では省略する
 type HistoryPoint struct {
-	Timestamp   string  `json:"timestamp"`
-	Hyperliquid float64 `json:"hyperliquid"`
-	Lighter     float64 `json:"lighter"`
-	Aster       float64 `json:"aster"`
-	Spread      float64 `json:"spread"`
-	SpreadPct   float64 `json:"spread_pct"`
+	Timestamp string             `json:"timestamp"`
+	Prices    map[string]float64 `json:"prices"`
+	Min       map[string]float64 `json:"min"`
+	Max       map[string]float64 `json:"max"`
+	Spread    float64            `json:"spread"`
+	SpreadPct float64            `json:"spread_pct"`
+	FairValue float64            `json:"fair_value,omitempty"`
+	Premiums  map[string]float64 `json:"premiums,omitempty"`
 }
 
 type MaxSpreadInfo struct {
@@ -44,127 +77,124 @@ type MaxSpreadInfo struct {
 }
 
 type SpreadStats struct {
-	MaxSpread     *MaxSpreadInfo `json:"max_spread"`
-	AvgSpread     float64        `json:"avg_spread"`
-	AvgSpreadPct  float64        `json:"avg_spread_pct"`
-	AvgPrice      float64        `json:"avg_price"`
-	PeriodMinutes int            `json:"period_minutes"`
+	MaxSpread     *MaxSpreadInfo     `json:"max_spread"`
+	AvgSpread     float64            `json:"avg_spread"`
+	AvgSpreadPct  float64            `json:"avg_spread_pct"`
+	AvgPrice      float64            `json:"avg_price"`
+	AvgPremiumPct map[string]float64 `json:"avg_premium_pct"` // DEX の取引所キー → 期間内の同じ単位の公正価格に対する平均乖離率（%）
+	PeriodMinutes int                `json:"period_minutes"`
+	From          string             `json:"from"`
+	To            string             `json:"to"`
+	BucketSeconds int                `json:"bucket_seconds"`
 }
 
+// ArbitrageInfo はアービトラージ機会。SpreadAbs / SpreadPct は手数料控除前（グロス）の値。
+// MixedQuote は買いと売りで価格の単位（USD / USDT）が異なるペアで、スプレッドに USDT/USD の乖離を含む
 type ArbitrageInfo struct {
-	BuyExchange  string  `json:"buy_exchange"`
-	SellExchange string  `json:"sell_exchange"`
-	BuyPrice     float64 `json:"buy_price"`
-	SellPrice    float64 `json:"sell_price"`
-	SpreadAbs    float64 `json:"spread_abs"`
-	SpreadPct    float64 `json:"spread_pct"`
+	BuyExchange     string  `json:"buy_exchange"`
+	SellExchange    string  `json:"sell_exchange"`
+	BuyExchangeKey  string  `json:"buy_exchange_key"`
+	SellExchangeKey string  `json:"sell_exchange_key"`
+	BuyPrice        float64 `json:"buy_price"`
+	SellPrice       float64 `json:"sell_price"`
+	SpreadAbs       float64 `json:"spread_abs"`
+	SpreadPct       float64 `json:"spread_pct"`
+	TotalFees       float64 `json:"total_fees"`
+	NetSpread       float64 `json:"net_spread"`
+	NetPct          float64 `json:"net_pct"`
+	MixedQuote      bool    `json:"mixed_quote"`
+}
+
+// SpreadOptions はスプレッド計算の時刻の扱い。
+// MaxSkew > 0 なら時刻が MaxSkew 以上離れた気配値同士をアービトラージのペアにしない。
+// 比較はペアの両方が取引所の付与した時刻を持ち AlignOnExchangeTime が true ならその時刻、それ以外は受信時刻で行う。
+// StaleAfter > 0 なら受信から StaleAfter 以上経った気配値を古いとみなし、
+// アービトラージの計算と履歴の前方補完に使わない。
+// RawRetention > 0 なら履歴は生の価格データが残っている直近 RawRetention に限る
+type SpreadOptions struct {
+	AlignOnExchangeTime bool
+	MaxSkew             time.Duration
+	StaleAfter          time.Duration
+	RawRetention        time.Duration
+}
+
+// MarketHealth は価格取得の成否からマーケットの気配値を使えるかを返す（HealthService）
+type MarketHealth interface {
+	MarketHealthy(exchangeKey, symbol string) bool
 }
 
 type SpreadService struct {
 	marketRepo repository.MarketRepository
 	priceRepo  repository.PriceRepository
+	health     MarketHealth
+	opts       SpreadOptions
 }
 
+// NewSpreadService は health が nil なら取得の成否を見ずに気配値の古さだけで判定する
 func NewSpreadService(
 	marketRepo repository.MarketRepository,
 	priceRepo repository.PriceRepository,
+	health MarketHealth,
+	opts SpreadOptions,
 ) *SpreadService {
 	return &SpreadService{
 		marketRepo: marketRepo,
 		priceRepo:  priceRepo,
+		health:     health,
+		opts:       opts,
 	}
 }
 
-func (s *SpreadService) CalculateSpread(ctx context.Context) (*SpreadResult, error) {
-	markets, err := s.marketRepo.FindAll(ctx)
-	if err != nil {
+func (s *SpreadService) CalculateSpread(ctx context.Context, asset string, query HistoryQuery) (*SpreadResult, error) {
+	if err := query.Validate(); err != nil {
 		return nil, err
 	}
-
-	var prices []PriceInfo
-
-	type exchangePrice struct {
-		name string
-		bid  float64
-		ask  float64
+	if err := query.ValidateRetention(time.Now(), s.opts.RawRetention); err != nil {
+		return nil, err
 	}
-	var exchangePrices []exchangePrice
-
-	// マーケットIDとキーのマッピング
-	marketKeyToID := make(map[string]uint)
-
-	for _, market := range markets {
-		latestPrice, err := s.priceRepo.FindLatestByMarket(ctx, market.ID)
-		if err != nil {
-			continue
-		}
-
-		midPrice := (latestPrice.Bid + latestPrice.Ask) / 2
-		info := PriceInfo{
-			ExchangeKey:  market.Exchange.Key,
-			ExchangeName: market.Exchange.DisplayName,
-			Bid:          latestPrice.Bid,
-			Ask:          latestPrice.Ask,
-			MidPrice:     midPrice,
-		}
-		prices = append(prices, info)
-
-		exchangePrices = append(exchangePrices, exchangePrice{
-			name: market.Exchange.DisplayName,
-			bid:  latestPrice.Bid,
-			ask:  latestPrice.Ask,
-		})
 
-		marketKeyToID[market.Exchange.Key] = market.ID
+	markets, err := s.findMarkets(ctx, asset)
+	if err != nil {
+		return nil, err
 	}
 
+	quotes, refQuotes := splitReferences(s.latestQuotes(ctx, markets))
+	fairValues := newFairValueInfos(freshQuotes(refQuotes))
+	prices := priceInfos(quotes, fairValues)
+	references := priceInfos(refQuotes, fairValues)
+
 	var buyOpp, sellOpp *ArbitrageInfo
 
-	for i, ep1 := range exchangePrices {
-		for j, ep2 := range exchangePrices {
-			if i == j {
+	quotes = freshQuotes(quotes)
+	for i, ep1 := range quotes {
+		for j, ep2 := range quotes {
+			if i == j || s.skewed(ep1, ep2) {
 				continue
 			}
 
 			// ep1で買って(ask)、ep2で売る(bid)
-			spread := ep2.bid - ep1.ask
-			if spread > 0 {
-				pct := (spread / ep1.ask) * 100
-				if buyOpp == nil || spread > buyOpp.SpreadAbs {
-					buyOpp = &ArbitrageInfo{
-						BuyExchange:  ep1.name,
-						SellExchange: ep2.name,
-						BuyPrice:     ep1.ask,
-						SellPrice:    ep2.bid,
-						SpreadAbs:    spread,
-						SpreadPct:    pct,
-					}
-				}
+			opp := newArbitrageInfo(ep1, ep2)
+			if opp.NetSpread > 0 && (buyOpp == nil || opp.NetSpread > buyOpp.NetSpread) {
+				buyOpp = opp
 			}
 
 			// ep1で売って(bid)、ep2で買う(ask) → 逆方向
-			spreadRev := ep1.bid - ep2.ask
-			if spreadRev > 0 {
-				pct := (spreadRev / ep2.ask) * 100
-				if sellOpp == nil || spreadRev > sellOpp.SpreadAbs {
-					sellOpp = &ArbitrageInfo{
-						BuyExchange:  ep2.name,
-						SellExchange: ep1.name,
-						BuyPrice:     ep2.ask,
-						SellPrice:    ep1.bid,
-						SpreadAbs:    spreadRev,
-						SpreadPct:    pct,
-					}
-				}
+			oppRev := newArbitrageInfo(ep2, ep1)
+			if oppRev.NetSpread > 0 && (sellOpp == nil || oppRev.NetSpread > sellOpp.NetSpread) {
+				sellOpp = oppRev
 			}
 		}
 	}
 
 	// 履歴データと統計情報を取得
-	history, stats := s.calculateHistoryAndStats(ctx, marketKeyToID)
+	history, stats := s.calculateHistoryAndStats(ctx, markets, query)
 
 	return &SpreadResult{
+		Asset:           asset,
 		Prices:          prices,
+		References:      references,
+		FairValue:       primaryFairValue(fairValues),
+		FairValues:      fairValues,
 		BuyOpportunity:  buyOpp,
 		SellOpportunity: sellOpp,
 		History:         history,
@@ -172,60 +202,331 @@ func (s *SpreadService) CalculateSpread(ctx context.Context) (*SpreadResult, err
 	}, nil
 }
 
-func (s *SpreadService) calculateHistoryAndStats(ctx context.Context, marketKeyToID map[string]uint) ([]HistoryPoint, *SpreadStats) {
-	periodMinutes := 15
-	now := time.Now()
-	from := now.Add(-time.Duration(periodMinutes) * time.Minute)
+// CalculatePairSpreads は asset の最新価格から全ての (買い, 売り) DEX ペアの損益を計算する
+func (s *SpreadService) CalculatePairSpreads(ctx context.Context, asset string) ([]*ArbitrageInfo, error) {
+	markets, err := s.findMarkets(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, _ := splitReferences(s.latestQuotes(ctx, markets))
+	quotes = freshQuotes(quotes)
 
-	// 各取引所の履歴を取得
-	type priceData struct {
-		ts       time.Time
-		midPrice float64
+	var pairs []*ArbitrageInfo
+	for i, buy := range quotes {
+		for j, sell := range quotes {
+			if i == j || s.skewed(buy, sell) {
+				continue
+			}
+			pairs = append(pairs, newArbitrageInfo(buy, sell))
+		}
 	}
-	exchangeHistory := make(map[string][]priceData)
+	return pairs, nil
+}
 
-	for key, marketID := range marketKeyToID {
-		prices, err := s.priceRepo.FindByMarketAndTimeRange(ctx, marketID, from, now)
-		if err != nil {
-			continue
+// findMarkets は asset の全取引所のマーケットを返す。1件もなければ ErrUnknownAsset
+func (s *SpreadService) findMarkets(ctx context.Context, asset string) ([]model.Market, error) {
+	markets, err := s.marketRepo.FindByBaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+	}
+	return markets, nil
+}
+
+// exchangeQuote は取引所ごとの最新の気配値
+type exchangeQuote struct {
+	key        string
+	name       string
+	bid        float64
+	ask        float64
+	takerFee   float64
+	quoteAsset string
+	receivedAt time.Time
+	exchangeTs *time.Time // 取引所が付与した時刻（返さない取引所は nil）
+	stale      bool
+	reference  bool
+}
+
+func (q exchangeQuote) mid() float64 {
+	return (q.bid + q.ask) / 2
+}
+
+// splitReferences は気配値を DEX と参照取引所に分ける
+func splitReferences(quotes []exchangeQuote) (dexes, references []exchangeQuote) {
+	for _, q := range quotes {
+		if q.reference {
+			references = append(references, q)
+		} else {
+			dexes = append(dexes, q)
 		}
-		for _, p := range prices {
-			exchangeHistory[key] = append(exchangeHistory[key], priceData{
-				ts:       p.Ts,
-				midPrice: (p.Bid + p.Ask) / 2,
-			})
+	}
+	return dexes, references
+}
+
+// newFairValueInfos は参照取引所を価格の単位ごとに分け、それぞれの仲値の中央値を公正価格とする。
+// USD と USDT の価格は換算しないため、単位をまたいで中央値を取らない。単位の順に並べる
+func newFairValueInfos(references []exchangeQuote) []*FairValueInfo {
+	byQuote := make(map[string][]exchangeQuote)
+	for _, q := range references {
+		byQuote[q.quoteAsset] = append(byQuote[q.quoteAsset], q)
+	}
+
+	fairValues := make([]*FairValueInfo, 0, len(byQuote))
+	for quoteAsset, quotes := range byQuote {
+		mids := make([]float64, 0, len(quotes))
+		sources := make([]string, 0, len(quotes))
+		for _, q := range quotes {
+			mids = append(mids, q.mid())
+			sources = append(sources, q.key)
 		}
+		sort.Strings(sources)
+		fairValues = append(fairValues, &FairValueInfo{Price: median(mids), QuoteAsset: quoteAsset, Sources: sources})
 	}
+	sort.Slice(fairValues, func(i, j int) bool {
+		return fairValues[i].QuoteAsset < fairValues[j].QuoteAsset
+	})
+	return fairValues
+}
 
-	// タイムスタンプでマージして履歴ポイントを作成
-	// 全タイムスタンプを収集
-	allTimestamps := make(map[time.Time]bool)
-	for _, prices := range exchangeHistory {
-		for _, p := range prices {
-			// 秒単位で丸める
-			ts := p.ts.Truncate(time.Second)
-			allTimestamps[ts] = true
+// primaryFairValue は参照取引所が最も多い単位の公正価格を返す（同数なら単位の順で先のもの）。なければ nil
+func primaryFairValue(fairValues []*FairValueInfo) *FairValueInfo {
+	var primary *FairValueInfo
+	for _, fv := range fairValues {
+		if primary == nil || len(fv.Sources) > len(primary.Sources) {
+			primary = fv
 		}
 	}
+	return primary
+}
 
-	// ソート
-	var sortedTimestamps []time.Time
-	for ts := range allTimestamps {
-		sortedTimestamps = append(sortedTimestamps, ts)
+// fairValueFor は quoteAsset と同じ単位の公正価格を返す。なければ nil
+func fairValueFor(fairValues []*FairValueInfo, quoteAsset string) *FairValueInfo {
+	for _, fv := range fairValues {
+		if fv.QuoteAsset == quoteAsset {
+			return fv
+		}
 	}
-	sort.Slice(sortedTimestamps, func(i, j int) bool {
-		return sortedTimestamps[i].Before(sortedTimestamps[j])
-	})
+	return nil
+}
+
+// median は values の中央値を返す（偶数個なら中央2つの平均）。values は並べ替える
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// premiumPct は公正価格に対する price の乖離率（%）
+func premiumPct(price, fairValue float64) float64 {
+	return (price - fairValue) / fairValue * 100
+}
+
+func priceInfos(quotes []exchangeQuote, fairValues []*FairValueInfo) []PriceInfo {
+	prices := make([]PriceInfo, 0, len(quotes))
+	for _, q := range quotes {
+		info := PriceInfo{
+			ExchangeKey:  q.key,
+			ExchangeName: q.name,
+			Bid:          q.bid,
+			Ask:          q.ask,
+			MidPrice:     q.mid(),
+			QuoteAsset:   q.quoteAsset,
+			Stale:        q.stale,
+		}
+		if fairValue := fairValueFor(fairValues, q.quoteAsset); fairValue != nil {
+			premium := premiumPct(info.MidPrice, fairValue.Price)
+			info.PremiumPct = &premium
+		}
+		prices = append(prices, info)
+	}
+	return prices
+}
+
+// freshQuotes は古い気配値を除いたものを返す
+func freshQuotes(quotes []exchangeQuote) []exchangeQuote {
+	var fresh []exchangeQuote
+	for _, q := range quotes {
+		if !q.stale {
+			fresh = append(fresh, q)
+		}
+	}
+	return fresh
+}
 
-	// 各取引所の価格を時刻でインデックス化
-	exchangePriceByTime := make(map[string]map[time.Time]float64)
-	for key, prices := range exchangeHistory {
-		exchangePriceByTime[key] = make(map[time.Time]float64)
+func (s *SpreadService) latestQuotes(ctx context.Context, markets []model.Market) []exchangeQuote {
+	var quotes []exchangeQuote
+	now := time.Now()
+	for _, market := range markets {
+		latestPrice, err := s.priceRepo.FindLatestByMarket(ctx, market.ID)
+		if err != nil {
+			continue
+		}
+
+		quotes = append(quotes, exchangeQuote{
+			key:        market.Exchange.Key,
+			name:       market.Exchange.DisplayName,
+			bid:        latestPrice.Bid,
+			ask:        latestPrice.Ask,
+			takerFee:   market.Exchange.TakerFee,
+			quoteAsset: market.QuoteAsset,
+			receivedAt: latestPrice.Ts,
+			exchangeTs: latestPrice.ExchangeTs,
+			stale:      s.stale(market, latestPrice, now),
+			reference:  market.Exchange.Reference,
+		})
+	}
+	return quotes
+}
+
+// stale は気配値が古いか、マーケットの取得が失敗し続けているなら true を返す
+func (s *SpreadService) stale(market model.Market, latestPrice *model.Price, now time.Time) bool {
+	if s.opts.StaleAfter > 0 && now.Sub(latestPrice.Ts) >= s.opts.StaleAfter {
+		return true
+	}
+	return s.health != nil && !s.health.MarketHealthy(market.Exchange.Key, market.Symbol)
+}
+
+// skewed は a と b が別の時点の気配値（時刻が MaxSkew 以上離れている）なら true を返す。
+// 取引所の時刻とローカルの受信時刻は時計がずれているため混ぜずに、両方が持つ方の時刻で比べる
+func (s *SpreadService) skewed(a, b exchangeQuote) bool {
+	if s.opts.MaxSkew <= 0 {
+		return false
+	}
+	ta, tb := a.receivedAt, b.receivedAt
+	if s.opts.AlignOnExchangeTime && a.exchangeTs != nil && b.exchangeTs != nil {
+		ta, tb = *a.exchangeTs, *b.exchangeTs
+	}
+	d := ta.Sub(tb)
+	if d < 0 {
+		d = -d
+	}
+	return d >= s.opts.MaxSkew
+}
+
+// quoteTime は比較に使う気配値の時刻を返す
+func (s *SpreadService) quoteTime(p *model.Price) time.Time {
+	if s.opts.AlignOnExchangeTime && p.ExchangeTs != nil {
+		return *p.ExchangeTs
+	}
+	return p.Ts
+}
+
+// newArbitrageInfo は buy で ask を買い、sell で bid を売った場合の
+// 1単位あたりの損益を計算する。両レッグともテイカー約定を想定する
+func newArbitrageInfo(buy, sell exchangeQuote) *ArbitrageInfo {
+	spread := sell.bid - buy.ask
+	fees := buy.ask*buy.takerFee + sell.bid*sell.takerFee
+	net := spread - fees
+
+	return &ArbitrageInfo{
+		BuyExchange:     buy.name,
+		SellExchange:    sell.name,
+		BuyExchangeKey:  buy.key,
+		SellExchangeKey: sell.key,
+		BuyPrice:        buy.ask,
+		SellPrice:       sell.bid,
+		SpreadAbs:       spread,
+		SpreadPct:       (spread / buy.ask) * 100,
+		TotalFees:       fees,
+		NetSpread:       net,
+		NetPct:          (net / buy.ask) * 100,
+		MixedQuote:      buy.quoteAsset != sell.quoteAsset,
+	}
+}
+
+// midBucket はバケット内の仲値の集計
+type midBucket struct {
+	sum   float64
+	count int
+	min   float64
+	max   float64
+}
+
+// bucketMids は markets の仲値を取引所キー → バケット番号ごとに集計する
+func (s *SpreadService) bucketMids(ctx context.Context, markets []model.Market, query HistoryQuery, bucketCount int) (map[string]map[int]*midBucket, map[string]string) {
+	exchangeBuckets := make(map[string]map[int]*midBucket)
+	exchangeNames := make(map[string]string)
+
+	for _, market := range markets {
+		key := market.Exchange.Key
+		prices, err := s.priceRepo.FindByMarketAndTimeRange(ctx, market.ID, query.From, query.To)
+		if err != nil || len(prices) == 0 {
+			continue
+		}
+		exchangeNames[key] = market.Exchange.DisplayName
+		buckets := make(map[int]*midBucket)
 		for _, p := range prices {
-			ts := p.ts.Truncate(time.Second)
-			exchangePriceByTime[key][ts] = p.midPrice
+			idx := int(s.quoteTime(&p).Sub(query.From) / query.Bucket)
+			if idx < 0 {
+				idx = 0
+			}
+			if idx >= bucketCount {
+				idx = bucketCount - 1
+			}
+			mid := (p.Bid + p.Ask) / 2
+			agg, ok := buckets[idx]
+			if !ok {
+				agg = &midBucket{min: mid, max: mid}
+				buckets[idx] = agg
+			}
+			agg.sum += mid
+			agg.count++
+			if mid < agg.min {
+				agg.min = mid
+			}
+			if mid > agg.max {
+				agg.max = mid
+			}
+		}
+		exchangeBuckets[key] = buckets
+	}
+	return exchangeBuckets, exchangeNames
+}
+
+func (s *SpreadService) calculateHistoryAndStats(ctx context.Context, markets []model.Market, query HistoryQuery) ([]HistoryPoint, *SpreadStats) {
+	from, to, bucket := query.From, query.To, query.Bucket
+	bucketCount := int((to.Sub(from) + bucket - 1) / bucket)
+
+	// 各取引所の仲値をバケットごとに集計（参照取引所は公正価格の算出用に分ける）
+	// 公正価格は価格の単位ごとに求めるため、取引所キー → 単位と単位ごとの参照取引所の数も控える
+	var dexMarkets, refMarkets []model.Market
+	quoteAssets := make(map[string]string, len(markets))
+	refCounts := make(map[string]int)
+	for _, m := range markets {
+		quoteAssets[m.Exchange.Key] = m.QuoteAsset
+		if m.Exchange.Reference {
+			refMarkets = append(refMarkets, m)
+			refCounts[m.QuoteAsset]++
+		} else {
+			dexMarkets = append(dexMarkets, m)
 		}
 	}
+	var primaryQuote string
+	for quoteAsset, n := range refCounts {
+		if primaryQuote == "" || n > refCounts[primaryQuote] || (n == refCounts[primaryQuote] && quoteAsset < primaryQuote) {
+			primaryQuote = quoteAsset
+		}
+	}
+	exchangeBuckets, exchangeNames := s.bucketMids(ctx, dexMarkets, query, bucketCount)
+	refBuckets, _ := s.bucketMids(ctx, refMarkets, query, bucketCount)
+
+	// 取引所キーを固定順に並べる（表示・計算順を安定させるため）
+	var exchangeKeys []string
+	for key := range exchangeBuckets {
+		exchangeKeys = append(exchangeKeys, key)
+	}
+	sort.Strings(exchangeKeys)
+	var refKeys []string
+	for key := range refBuckets {
+		refKeys = append(refKeys, key)
+	}
+	sort.Strings(refKeys)
 
 	// 履歴ポイントを生成
 	var history []HistoryPoint
@@ -233,107 +534,125 @@ func (s *SpreadService) calculateHistoryAndStats(ctx context.Context, marketKeyT
 	var totalSpread, totalPrice float64
 	var spreadCount, priceCount int
 
-	// 直前の価格を保持（欠損値対応）
+	// 直前の価格を保持（欠損値対応）。StaleAfter を超えて更新がなければ補完しない
 	lastPrices := make(map[string]float64)
-
-	for _, ts := range sortedTimestamps {
+	lastSeen := make(map[string]int)
+	lastRefPrices := make(map[string]float64)
+	lastRefSeen := make(map[string]int)
+	premiumSums := make(map[string]float64)
+	premiumCounts := make(map[string]int)
+
+	for idx := 0; idx < bucketCount; idx++ {
+		ts := from.Add(time.Duration(idx) * bucket)
 		point := HistoryPoint{
 			Timestamp: ts.Format(time.RFC3339),
+			Prices:    make(map[string]float64, len(exchangeKeys)),
+			Min:       make(map[string]float64, len(exchangeKeys)),
+			Max:       make(map[string]float64, len(exchangeKeys)),
 		}
 
 		// 各取引所の価格を設定（なければ直前の値を使用）
-		for _, key := range []string{"hyperliquid", "lighter", "aster"} {
-			if price, ok := exchangePriceByTime[key][ts]; ok {
-				lastPrices[key] = price
-			}
-		}
-
-		point.Hyperliquid = lastPrices["hyperliquid"]
-		point.Lighter = lastPrices["lighter"]
-		point.Aster = lastPrices["aster"]
-
-		// スプレッド計算（最高値 - 最低値）
-		var validPrices []struct {
+		type namedPrice struct {
 			name  string
 			price float64
 		}
-		if point.Hyperliquid > 0 {
-			validPrices = append(validPrices, struct {
-				name  string
-				price float64
-			}{"HyperLiquid", point.Hyperliquid})
-		}
-		if point.Lighter > 0 {
-			validPrices = append(validPrices, struct {
-				name  string
-				price float64
-			}{"Lighter", point.Lighter})
-		}
-		if point.Aster > 0 {
-			validPrices = append(validPrices, struct {
-				name  string
-				price float64
-			}{"Aster", point.Aster})
+		var validPrices []namedPrice
+		expired := 0
+		for _, key := range exchangeKeys {
+			if agg, ok := exchangeBuckets[key][idx]; ok {
+				lastPrices[key] = agg.sum / float64(agg.count)
+				lastSeen[key] = idx
+				point.Min[key] = agg.min
+				point.Max[key] = agg.max
+			} else if s.opts.StaleAfter > 0 && lastPrices[key] > 0 &&
+				time.Duration(idx-lastSeen[key])*bucket > s.opts.StaleAfter {
+				expired++
+				continue
+			}
+			if price := lastPrices[key]; price > 0 {
+				point.Prices[key] = price
+				validPrices = append(validPrices, namedPrice{exchangeNames[key], price})
+			}
 		}
 
-		if len(validPrices) >= 2 {
-			// ソートして最高値と最低値を取得
-			sort.Slice(validPrices, func(i, j int) bool {
-				return validPrices[i].price > validPrices[j].price
-			})
-
-			highPrice := validPrices[0].price
-			lowPrice := validPrices[len(validPrices)-1].price
-			spread := highPrice - lowPrice
-			spreadPct := (spread / lowPrice) * 100
-
-			point.Spread = spread
-			point.SpreadPct = spreadPct
+		// 参照取引所の価格も同様に前方補完し、単位ごとの中央値を公正価格とする
+		refPrices := make(map[string][]float64)
+		for _, key := range refKeys {
+			if agg, ok := refBuckets[key][idx]; ok {
+				lastRefPrices[key] = agg.sum / float64(agg.count)
+				lastRefSeen[key] = idx
+			} else if s.opts.StaleAfter > 0 && lastRefPrices[key] > 0 &&
+				time.Duration(idx-lastRefSeen[key])*bucket > s.opts.StaleAfter {
+				continue
+			}
+			if price := lastRefPrices[key]; price > 0 {
+				refPrices[quoteAssets[key]] = append(refPrices[quoteAssets[key]], price)
+			}
+		}
 
-			totalSpread += spread
-			spreadCount++
+		// 期間内にデータのある全取引所（途絶したものを除く）の価格が揃っていないバケットは除外
+		if len(validPrices) < 2 || len(validPrices) != len(exchangeKeys)-expired {
+			continue
+		}
 
-			// 平均価格の計算用
-			for _, vp := range validPrices {
-				totalPrice += vp.price
-				priceCount++
+		if len(refPrices) > 0 {
+			fairValues := make(map[string]float64, len(refPrices))
+			for quoteAsset, values := range refPrices {
+				fairValues[quoteAsset] = median(values)
 			}
-
-			// 最大スプレッド更新
-			if maxSpread == nil || spread > maxSpread.Value {
-				maxSpread = &MaxSpreadInfo{
-					Value:        spread,
-					Pct:          spreadPct,
-					Timestamp:    ts.Format(time.RFC3339),
-					HighExchange: validPrices[0].name,
-					LowExchange:  validPrices[len(validPrices)-1].name,
-					HighPrice:    highPrice,
-					LowPrice:     lowPrice,
+			point.FairValue = fairValues[primaryQuote]
+			point.Premiums = make(map[string]float64, len(point.Prices))
+			for key, price := range point.Prices {
+				fairValue, ok := fairValues[quoteAssets[key]]
+				if !ok {
+					continue
 				}
+				premium := premiumPct(price, fairValue)
+				point.Premiums[key] = premium
+				premiumSums[key] += premium
+				premiumCounts[key]++
+			}
+			if len(point.Premiums) == 0 {
+				point.Premiums = nil
 			}
 		}
 
-		history = append(history, point)
-	}
+		// スプレッド計算（最高値 - 最低値）
+		sort.Slice(validPrices, func(i, j int) bool {
+			return validPrices[i].price > validPrices[j].price
+		})
 
-	// 3つの取引所すべての価格があるポイントのみをフィルタ
-	var validHistory []HistoryPoint
-	for _, p := range history {
-		if p.Hyperliquid > 0 && p.Lighter > 0 && p.Aster > 0 {
-			validHistory = append(validHistory, p)
+		highPrice := validPrices[0].price
+		lowPrice := validPrices[len(validPrices)-1].price
+		spread := highPrice - lowPrice
+		spreadPct := (spread / lowPrice) * 100
+
+		point.Spread = spread
+		point.SpreadPct = spreadPct
+
+		totalSpread += spread
+		spreadCount++
+
+		// 平均価格の計算用
+		for _, vp := range validPrices {
+			totalPrice += vp.price
+			priceCount++
 		}
-	}
-	history = validHistory
 
-	// サンプリング（最大180ポイント）
-	maxPoints := 180
-	if len(history) > maxPoints {
-		step := len(history) / maxPoints
-		var sampled []HistoryPoint
-		for i := 0; i < len(history); i += step {
-			sampled = append(sampled, history[i])
+		// 最大スプレッド更新
+		if maxSpread == nil || spread > maxSpread.Value {
+			maxSpread = &MaxSpreadInfo{
+				Value:        spread,
+				Pct:          spreadPct,
+				Timestamp:    ts.Format(time.RFC3339),
+				HighExchange: validPrices[0].name,
+				LowExchange:  validPrices[len(validPrices)-1].name,
+				HighPrice:    highPrice,
+				LowPrice:     lowPrice,
+			}
 		}
-		history = sampled
+
+		history = append(history, point)
 	}
 
 	// 統計情報を計算
@@ -348,13 +667,22 @@ func (s *SpreadService) calculateHistoryAndStats(ctx context.Context, marketKeyT
 		avgSpreadPct = (avgSpread / avgPrice) * 100
 	}
 
+	avgPremiumPct := make(map[string]float64, len(premiumSums))
+	for key, sum := range premiumSums {
+		avgPremiumPct[key] = sum / float64(premiumCounts[key])
+	}
+
 	stats := &SpreadStats{
 		MaxSpread:     maxSpread,
 		AvgSpread:     avgSpread,
 		AvgSpreadPct:  avgSpreadPct,
 		AvgPrice:      avgPrice,
-		PeriodMinutes: periodMinutes,
+		AvgPremiumPct: avgPremiumPct,
+		PeriodMinutes: int(to.Sub(from).Minutes()),
+		From:          from.Format(time.RFC3339),
+		To:            to.Format(time.RFC3339),
+		BucketSeconds: int(bucket.Seconds()),
 	}
 
 	return history, stats
-}
\ No newline at end of file
+}
@@ -8,6 +8,7 @@ import { FundingRates } from './components/FundingRates';
 import type { SpreadResult, FundingRate } from './types/spread';
 
 const API_URL = 'http://localhost:8080/api/spread';
+const STREAM_URL = 'http://localhost:8080/api/stream';
 
 function App() {
   const [data, setData] = useState<SpreadResult | null>(null);
@@ -32,8 +33,14 @@ function App() {
 
   useEffect(() => {
     fetchData();
-    const timer = setInterval(fetchData, 3000);
-    return () => clearInterval(timer);
+
+    // 以降の更新はサーバーからの push で受け取る
+    const source = new EventSource(STREAM_URL);
+    source.addEventListener('spread', (event) => {
+      setData(JSON.parse((event as MessageEvent).data) as SpreadResult);
+      setError(null);
+    });
+    return () => source.close();
   }, [fetchData]);
 
   // Mock funding rates
@@ -80,7 +87,7 @@ function App() {
       <Header />
       <main className="dashboard">
         <section className="top-section">
-          <LivePrices prices={data.prices} avgPrice={data.stats.avg_price} />
+          <LivePrices prices={data.prices} avgPrice={data.stats.avg_price} fairValues={data.fair_values ?? []} />
           <StatsCards
             stats={data.stats}
             topArb={data.buy_opportunity}
@@ -9,6 +9,10 @@ const exchangeColors: Record<string, string> = {
   Hyperliquid: '#58a6ff',
   Lighter: '#3fb950',
   Aster: '#d29922',
+  dYdX: '#a371f7',
+  Paradex: '#f778ba',
+  Vertex: '#39c5cf',
+  Drift: '#f0883e',
 };
 
 export function FundingRates({ rates }: Props) {
@@ -1,21 +1,31 @@
 import { useMemo } from 'react';
-import type { PriceInfo } from '../types/spread';
+import type { FairValueInfo, PriceInfo } from '../types/spread';
 
 interface Props {
   prices: PriceInfo[];
   avgPrice: number;
+  fairValues: FairValueInfo[];
 }
 
 const exchangeColors: Record<string, string> = {
   hyperliquid: '#58a6ff',
   lighter: '#3fb950',
   aster: '#d29922',
+  dydx: '#a371f7',
+  paradex: '#f778ba',
+  vertex: '#39c5cf',
+  drift: '#f0883e',
 };
 
-export function LivePrices({ prices, avgPrice }: Props) {
-  const calculateChange = (price: number) => {
+export function LivePrices({ prices, avgPrice, fairValues }: Props) {
+  const hasFairValue = fairValues.length > 0;
+
+  // Compare against the CEX fair value in the same quote asset when available, otherwise the DEX average.
+  // With a fair value but none in the row's quote asset, there is nothing to compare against (null)
+  const calculateChange = (price: PriceInfo) => {
+    if (hasFairValue) return price.premium_pct;
     if (avgPrice === 0) return 0;
-    return ((price - avgPrice) / avgPrice) * 100;
+    return ((price.mid_price - avgPrice) / avgPrice) * 100;
   };
 
   // Find min and max prices using mid_price from API
@@ -37,23 +47,29 @@ export function LivePrices({ prices, avgPrice }: Props) {
   return (
     <div className="card live-prices">
       <h2 className="card-title">Live Prices</h2>
+      {fairValues.map((fairValue) => (
+        <div key={fairValue.quote_asset} className="fair-value">
+          Fair value ({fairValue.quote_asset}) ${fairValue.price.toLocaleString(undefined, { minimumFractionDigits: 0, maximumFractionDigits: 0 })}
+          {' '}({fairValue.sources.join(', ')})
+        </div>
+      ))}
       <table className="prices-table">
         <thead>
           <tr>
             <th>DEX</th>
             <th>Price</th>
-            <th>vs Avg</th>
+            <th>{hasFairValue ? 'vs Fair' : 'vs Avg'}</th>
           </tr>
         </thead>
         <tbody>
           {prices.map((price) => {
-            const change = calculateChange(price.mid_price);
-            const isPositive = change >= 0;
+            const change = calculateChange(price);
+            const isPositive = change !== null && change >= 0;
             const colorKey = price.exchange_key.toLowerCase();
             const priceClass = getPriceClass(price.mid_price);
 
             return (
-              <tr key={price.exchange_key}>
+              <tr key={price.exchange_key} className={price.stale ? 'stale' : undefined}>
                 <td>
                   <span
                     className="exchange-indicator"
@@ -66,9 +82,13 @@ export function LivePrices({ prices, avgPrice }: Props) {
                     ${price.mid_price.toLocaleString(undefined, { minimumFractionDigits: 0, maximumFractionDigits: 0 })}
                   </span>
                 </td>
-                <td className={`change-cell ${isPositive ? 'positive' : 'negative'}`}>
-                  {isPositive ? '+' : ''}{change.toFixed(2)}%
-                </td>
+                {change === null ? (
+                  <td className="change-cell" title={`No ${price.quote_asset} reference price`}>—</td>
+                ) : (
+                  <td className={`change-cell ${isPositive ? 'positive' : 'negative'}`}>
+                    {isPositive ? '+' : ''}{change.toFixed(2)}%
+                  </td>
+                )}
               </tr>
             );
           })}
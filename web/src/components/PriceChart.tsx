@@ -91,7 +91,7 @@ export function PriceChart({ history, stats }: Props) {
     return { formattedData: formatted, tickIndices: indices };
   }, [history]);
 
-  const allPrices = history.flatMap((d) => [d.hyperliquid, d.lighter, d.aster]).filter((p) => p > 0);
+  const allPrices = history.flatMap((d) => Object.values(d.prices)).filter((p) => p > 0);
   const minPrice = allPrices.length > 0 ? Math.min(...allPrices) * 0.9995 : 0;
   const maxPrice = allPrices.length > 0 ? Math.max(...allPrices) * 1.0005 : 100000;
 
@@ -174,7 +174,7 @@ export function PriceChart({ history, stats }: Props) {
 
             <Area
               type="monotone"
-              dataKey="hyperliquid"
+              dataKey="prices.hyperliquid"
               name="Hyperliquid"
               stroke="#58a6ff"
               strokeWidth={2}
@@ -182,7 +182,7 @@ export function PriceChart({ history, stats }: Props) {
             />
             <Area
               type="monotone"
-              dataKey="lighter"
+              dataKey="prices.lighter"
               name="Lighter"
               stroke="#3fb950"
               strokeWidth={2}
@@ -190,7 +190,7 @@ export function PriceChart({ history, stats }: Props) {
             />
             <Area
               type="monotone"
-              dataKey="aster"
+              dataKey="prices.aster"
               name="Aster"
               stroke="#d29922"
               strokeWidth={2}
@@ -32,6 +32,7 @@ export function StatsCards({ stats, topArb }: Props) {
               <span className="arb-long">Long {topArb.buy_exchange}</span>
               <span className="arb-sep">/</span>
               <span className="arb-short">Short {topArb.sell_exchange}</span>
+              {topArb.mixed_quote && <span className="arb-mixed" title="USD and USDT legs: the spread includes the USDT/USD basis">USD/USDT</span>}
             </div>
           ) : (
             <span className="stat-none">No opportunity</span>
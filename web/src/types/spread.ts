@@ -4,24 +4,41 @@ export interface PriceInfo {
   bid: number;
   ask: number;
   mid_price: number;
+  quote_asset: string;
+  stale: boolean;
+  premium_pct: number | null;
+}
+
+export interface FairValueInfo {
+  price: number;
+  quote_asset: string;
+  sources: string[];
 }
 
 export interface ArbitrageInfo {
   buy_exchange: string;
   sell_exchange: string;
+  buy_exchange_key: string;
+  sell_exchange_key: string;
   buy_price: number;
   sell_price: number;
   spread_abs: number;
   spread_pct: number;
+  total_fees: number;
+  net_spread: number;
+  net_pct: number;
+  mixed_quote: boolean;
 }
 
 export interface HistoryPoint {
   timestamp: string;
-  hyperliquid: number;
-  lighter: number;
-  aster: number;
+  prices: Record<string, number>;
+  min: Record<string, number>;
+  max: Record<string, number>;
   spread: number;
   spread_pct: number;
+  fair_value?: number;
+  premiums?: Record<string, number>;
 }
 
 export interface MaxSpreadInfo {
@@ -39,11 +56,19 @@ export interface SpreadStats {
   avg_spread: number;
   avg_spread_pct: number;
   avg_price: number;
+  avg_premium_pct: Record<string, number>;
   period_minutes: number;
+  from: string;
+  to: string;
+  bucket_seconds: number;
 }
 
 export interface SpreadResult {
+  asset: string;
   prices: PriceInfo[];
+  references: PriceInfo[];
+  fair_value: FairValueInfo | null;
+  fair_values: FairValueInfo[];
   buy_opportunity: ArbitrageInfo | null;
   sell_opportunity: ArbitrageInfo | null;
   history: HistoryPoint[];
@@ -55,3 +80,15 @@ export interface FundingRate {
   rate: number;
   nextUpdate: Date;
 }
+
+export interface ExchangeStatus {
+  exchange_key: string;
+  exchange_name: string;
+  healthy: boolean;
+  last_success: string | null;
+  last_failure: string | null;
+  last_error: string;
+  consecutive_failures: number;
+  latency_p50_ms: number;
+  latency_p99_ms: number;
+}